@@ -0,0 +1,176 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// defaultBulkChunkSize is the default maximum number of objects created
+// with a single request when creating objects in bulk.
+const defaultBulkChunkSize = 1000
+
+// BulkResult represents the result of creating a single object as part of
+// a bulk create.
+type BulkResult struct {
+	// Name of the object.
+	Name string
+
+	// Err is nil when the object was created successfully, or reports why
+	// the object could not be created. Errors returned by KEPServerEX are of
+	// type *ErrorResponse.
+	Err error
+}
+
+// bulkCreate creates the given objects in the collection with the given path.
+// The objects are sent in chunks, each chunk being created with a single
+// request. KEPServerEX answers with a 201 when all objects of a chunk were
+// created, or with a 207 and a result per object when some of them failed.
+//
+// A result is returned for every object which creation was attempted. If a
+// chunk could not be sent at all, or failed as a whole, no further chunks are
+// sent and the results for the previous chunks and for the objects of the
+// chunk that failed the check are returned together with the error.
+func (c *Client) bulkCreate(path string, objects []interface{}, check func(interface{}) error, options []RequestOptionFunc) ([]*BulkResult, error) {
+	results := make([]*BulkResult, 0, len(objects))
+
+	for start := 0; start < len(objects); start += c.bulkChunkSize {
+		end := start + c.bulkChunkSize
+		if end > len(objects) {
+			end = len(objects)
+		}
+
+		// Objects failing the check are not sent, but do get a result.
+		var chunk []interface{}
+		var chunkResults, sent []*BulkResult
+		for _, v := range objects[start:end] {
+			result := &BulkResult{Name: objectName(v)}
+			chunkResults = append(chunkResults, result)
+			if check != nil {
+				if result.Err = check(v); result.Err != nil {
+					continue
+				}
+			}
+			chunk = append(chunk, v)
+			sent = append(sent, result)
+		}
+		if len(chunk) == 0 {
+			results = append(results, chunkResults...)
+			continue
+		}
+
+		req, err := c.NewRequest("POST", path, chunk, options...)
+		if err != nil {
+			return append(results, checkFailures(chunkResults)...), err
+		}
+
+		body := &bytes.Buffer{}
+		resp, err := c.do(req, body)
+
+		var errResp *ErrorResponse
+		switch {
+		case errors.As(err, &errResp):
+			// Some versions answer with an error status when none of the
+			// objects could be created, while still including the result
+			// per object. Otherwise the error applies to the whole chunk.
+			if !setBulkResults(sent, errResp.Body, resp) {
+				return append(results, checkFailures(chunkResults)...), err
+			}
+		case err != nil:
+			return append(results, checkFailures(chunkResults)...), err
+		case resp.StatusCode == http.StatusMultiStatus:
+			// Without a result per object it is unknown which objects were
+			// created, so none of them are reported as created.
+			if !setBulkResults(sent, body.Bytes(), resp) {
+				errResp := &ErrorResponse{
+					Code:     resp.StatusCode,
+					Message:  "response does not contain a result for each object",
+					Body:     body.Bytes(),
+					Response: resp,
+				}
+				for _, result := range sent {
+					result.Err = errResp
+				}
+			}
+		}
+
+		results = append(results, chunkResults...)
+	}
+
+	return results, nil
+}
+
+// checkFailures returns the results of the objects which were not sent
+// because they failed the check.
+func checkFailures(results []*BulkResult) []*BulkResult {
+	var failed []*BulkResult
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// setBulkResults sets the errors of the given results using the result per
+// object in the given response body. It returns false if the body does not
+// contain a result for each object.
+func setBulkResults(results []*BulkResult, data []byte, resp *http.Response) bool {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil || len(items) != len(results) {
+		return false
+	}
+
+	for i, item := range items {
+		errs := parseErrors(item)
+		if len(errs) == 0 {
+			continue
+		}
+		// Items without a code, or with a success code, were created.
+		e := errs[0]
+		if e.Code < 300 {
+			continue
+		}
+		results[i].Err = &ErrorResponse{
+			Code:       e.Code,
+			Message:    e.Message,
+			Properties: e.properties(),
+			Body:       item,
+			Response:   resp,
+		}
+	}
+
+	return true
+}
+
+// objectName returns the name of the given object options.
+func objectName(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+
+	var object struct {
+		Name string `json:"common.ALLTYPES_NAME"`
+	}
+	json.Unmarshal(data, &object)
+
+	return object.Name
+}
@@ -0,0 +1,231 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestBulkCreateMultiStatus(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/config/v1/project/channels/Channel1/devices/Device1/tags", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMultiStatus)
+		io.WriteString(w, `[
+			{"common.ALLTYPES_NAME":"Tag1"},
+			{"code":400,"message":"Validation failed on property common.ALLTYPES_NAME in object definition at line 1: The name 'Tag2' is already used.","property":"common.ALLTYPES_NAME","description":"The name 'Tag2' is already used."},
+			{"code":201,"message":"Created"},
+			{"code":400,"message":"Validation failed on property servermain.TAG_ADDRESS in object definition at line 3: Invalid address."}
+		]`)
+	})
+
+	results, err := client.Tags.CreateTags("Channel1", "Device1", "", []*TagOptions{
+		{Name: String("Tag1")},
+		{Name: String("Tag2")},
+		{Name: String("Tag3")},
+		{Name: String("Tag4")},
+	})
+	if err != nil {
+		t.Fatalf("CreateTags returned error: %v", err)
+	}
+
+	want := []struct {
+		name string
+		err  error
+	}{
+		{"Tag1", nil},
+		{"Tag2", ErrAlreadyExists},
+		{"Tag3", nil},
+		{"Tag4", ErrInvalidProperty},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("CreateTags returned %d results, want %d", len(results), len(want))
+	}
+	for i, w := range want {
+		r := results[i]
+		if r.Name != w.name {
+			t.Errorf("result %d is for %q, want %q", i, r.Name, w.name)
+		}
+		if w.err == nil && r.Err != nil {
+			t.Errorf("result for %s has error %v, want none", r.Name, r.Err)
+		}
+		if w.err != nil && !errors.Is(r.Err, w.err) {
+			t.Errorf("result for %s has error %v, want %v", r.Name, r.Err, w.err)
+		}
+	}
+}
+
+func TestBulkCreateTransportError(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	var mu sync.Mutex
+	posts := 0
+
+	mux.HandleFunc("/config/v1/project/channels", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		posts++
+		post := posts
+		mu.Unlock()
+
+		if post > 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("failed to hijack connection: %v", err)
+				return
+			}
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	client.SetBulkChunkSize(2)
+
+	results, err := client.Channels.CreateChannels([]interface{}{
+		&ChannelOptions{Name: "Channel1", Driver: SimulatorDriver},
+		&ChannelOptions{Name: "Channel2", Driver: SimulatorDriver},
+		&ChannelOptions{Name: "Channel3", Driver: SimulatorDriver, COMID: Int(1)},
+		&ChannelOptions{Name: "Channel4", Driver: SimulatorDriver},
+		&ChannelOptions{Name: "Channel5", Driver: SimulatorDriver},
+	})
+	if err == nil {
+		t.Fatal("CreateChannels returned no error")
+	}
+
+	// The results of the first chunk and of the channel failing the
+	// check in the second chunk must be returned.
+	if len(results) != 3 {
+		t.Fatalf("CreateChannels returned %d results, want 3", len(results))
+	}
+	for i, name := range []string{"Channel1", "Channel2", "Channel3"} {
+		if results[i].Name != name {
+			t.Errorf("result %d is for %q, want %q", i, results[i].Name, name)
+		}
+	}
+	if results[0].Err != nil || results[1].Err != nil {
+		t.Errorf("results of the first chunk have errors %v and %v, want none", results[0].Err, results[1].Err)
+	}
+	if results[2].Err == nil {
+		t.Error("result for Channel3 has no error, want the serial settings error")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if posts != 2 {
+		t.Errorf("server received %d requests, want 2", posts)
+	}
+}
+
+func TestBulkCreateInvalidMultiStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"malformed body", `[{"code":400,`},
+		{"wrong item count", `[{"code":201,"message":"Created"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, server, client := setup(t)
+			defer teardown(server)
+
+			mux.HandleFunc("/config/v1/project/channels/Channel1/devices/Device1/tags", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusMultiStatus)
+				io.WriteString(w, tt.body)
+			})
+
+			results, err := client.Tags.CreateTags("Channel1", "Device1", "", []*TagOptions{
+				{Name: String("Tag1")},
+				{Name: String("Tag2")},
+			})
+			if err != nil {
+				t.Fatalf("CreateTags returned error: %v", err)
+			}
+			if len(results) != 2 {
+				t.Fatalf("CreateTags returned %d results, want 2", len(results))
+			}
+
+			// None of the objects may be reported as created.
+			for _, r := range results {
+				errResp, ok := r.Err.(*ErrorResponse)
+				if !ok {
+					t.Errorf("result for %s has error %v, want an *ErrorResponse", r.Name, r.Err)
+					continue
+				}
+				if errResp.Code != http.StatusMultiStatus {
+					t.Errorf("result for %s has code %d, want %d", r.Name, errResp.Code, http.StatusMultiStatus)
+				}
+			}
+		})
+	}
+}
+
+func TestBulkCreateChunkError(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	var mu sync.Mutex
+	posts := 0
+
+	mux.HandleFunc("/config/v1/project/channels", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		posts++
+		post := posts
+		mu.Unlock()
+
+		if post > 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	client.SetBulkChunkSize(2)
+
+	results, err := client.Channels.CreateChannels([]interface{}{
+		&ChannelOptions{Name: "Channel1", Driver: SimulatorDriver},
+		&ChannelOptions{Name: "Channel2", Driver: SimulatorDriver},
+		&ChannelOptions{Name: "Channel3", Driver: SimulatorDriver},
+		&ChannelOptions{Name: "Channel4", Driver: SimulatorDriver},
+		&ChannelOptions{Name: "Channel5", Driver: SimulatorDriver},
+		&ChannelOptions{Name: "Channel6", Driver: SimulatorDriver},
+	})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("CreateChannels returned error %v, want %v", err, ErrUnauthorized)
+	}
+
+	// Only the results of the first chunk must be returned.
+	if len(results) != 2 {
+		t.Fatalf("CreateChannels returned %d results, want 2", len(results))
+	}
+	for i, name := range []string{"Channel1", "Channel2"} {
+		if results[i].Name != name || results[i].Err != nil {
+			t.Errorf("result %d is for %q with error %v, want %q without error", i, results[i].Name, results[i].Err, name)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if posts != 2 {
+		t.Errorf("server received %d requests, want 2", posts)
+	}
+}
@@ -19,6 +19,7 @@ package kepserverex
 import (
 	"fmt"
 	"net/url"
+	"sync"
 )
 
 // ChannelService handles communication with the channel related methods
@@ -29,33 +30,199 @@ type ChannelService struct {
 
 // Channel represents a KEPServerEX channel.
 type Channel struct {
-	Name                string              `json:"common.ALLTYPES_NAME"`
-	Description         string              `json:"common.ALLTYPES_DESCRIPTION"`
-	UniqueID            int64               `json:"servermain.CHANNEL_UNIQUE_ID"`
-	ProjectID           int64               `json:"PROJECT_ID"`
-	Driver              string              `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER"`
-	DiagnosticsCapture  bool                `json:"servermain.CHANNEL_DIAGNOSTICS_CAPTURE"`
-	NetworkAdapter      string              `json:"servermain.CHANNEL_ETHERNET_COMMUNICATIONS_NETWORK_ADAPTER_STRING"`
-	OptimizationMethod  OptimizationMethod  `json:"servermain.CHANNEL_WRITE_OPTIMIZATIONS_METHOD"`
-	DutyCycle           int                 `json:"servermain.CHANNEL_WRITE_OPTIMIZATIONS_DUTY_CYCLE"`
-	FloatingPointValues FloatingPointValues `json:"servermain.CHANNEL_NON_NORMALIZED_FLOATING_POINT_HANDLING"`
+	Name                        string              `json:"common.ALLTYPES_NAME"`
+	Description                 string              `json:"common.ALLTYPES_DESCRIPTION"`
+	UniqueID                    int64               `json:"servermain.CHANNEL_UNIQUE_ID"`
+	ProjectID                   int64               `json:"PROJECT_ID"`
+	Driver                      string              `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER"`
+	DiagnosticsCapture          bool                `json:"servermain.CHANNEL_DIAGNOSTICS_CAPTURE"`
+	StaticTagCount              int                 `json:"servermain.CHANNEL_STATIC_TAG_COUNT"`
+	NetworkAdapter              string              `json:"servermain.CHANNEL_ETHERNET_COMMUNICATIONS_NETWORK_ADAPTER_STRING"`
+	OptimizationMethod          OptimizationMethod  `json:"servermain.CHANNEL_WRITE_OPTIMIZATIONS_METHOD"`
+	DutyCycle                   int                 `json:"servermain.CHANNEL_WRITE_OPTIMIZATIONS_DUTY_CYCLE"`
+	FloatingPointValues         FloatingPointValues `json:"servermain.CHANNEL_NON_NORMALIZED_FLOATING_POINT_HANDLING"`
+	InterDeviceDelay            int                 `json:"servermain.CHANNEL_INTER_DEVICE_DELAY_MS"`
+	VirtualNetwork              VirtualNetwork      `json:"servermain.CHANNEL_COMMUNICATIONS_SERIALIZATION_VIRTUAL_NETWORK"`
+	TransactionsPerCycle        int                 `json:"servermain.CHANNEL_COMMUNICATIONS_SERIALIZATION_TRANSACTIONS_PER_CYCLE"`
+	NetworkMode                 NetworkMode         `json:"servermain.CHANNEL_COMMUNICATIONS_SERIALIZATION_NETWORK_MODE"`
+	EncapsulationNetworkAdapter string              `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_NETWORK_ADAPTER_STRING"`
+	PhysicalMedium              PhysicalMedium      `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_PHYSICAL_MEDIUM"`
+	COMID                       int                 `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_COM_ID"`
+	BaudRate                    BautRate            `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_BAUD_RATE"`
+	DataBits                    DataBits            `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_DATA_BITS"`
+	Parity                      Parity              `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_PARITY"`
+	StopBits                    StopBits            `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_STOP_BITS"`
+	FlowControl                 FlowControl         `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_FLOW_CONTROL"`
+	RTSLineRaise                int                 `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_RTS_LINE_RAISE_MS"`
+	RTSLineDrop                 int                 `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_RTS_LINE_DROP_MS"`
+	RTSPollWithEchoSuppression  bool                `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_RTS_LINE_POLL_WITH_ECHO_SUPPRESSION"`
+	CloseIdleConnection         bool                `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_CLOSE_IDLE_CONNECTION"`
+	IdleTimeToClose             int                 `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_IDLE_TIME_TO_CLOSE_SECONDS"`
+	ModemName                   string              `json:"servermain.CHANNEL_MODEM_SETTINGS_MODEM_STRING"`
+	ModemConnectTimeout         int                 `json:"servermain.CHANNEL_MODEM_SETTINGS_CONNECT_TIMEOUT_SECONDS"`
+	ModemAutoDial               bool                `json:"servermain.CHANNEL_MODEM_SETTINGS_AUTO_DIAL"`
+	ModemReportCommErrors       bool                `json:"servermain.CHANNEL_MODEM_SETTINGS_REPORT_COMMUNICATION_ERRORS"`
+	ModemCloseIdleConnection    bool                `json:"servermain.CHANNEL_MODEM_SETTINGS_CLOSE_IDLE_CONNECTION"`
+	ModemIdleTimeToClose        int                 `json:"servermain.CHANNEL_MODEM_SETTINGS_IDLE_TIME_TO_CLOSE_SECONDS"`
+	ModemReadProcessing         ReadProcessing      `json:"servermain.CHANNEL_MODEM_SETTINGS_READ_PROCESSING"`
 }
 
 // ChannelOptions represents all channel options.
 type ChannelOptions struct {
-	Name                string              `json:"common.ALLTYPES_NAME,omitempty"`
-	Description         string              `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
-	Driver              string              `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER,omitempty"`
-	DiagnosticsCapture  bool                `json:"servermain.CHANNEL_DIAGNOSTICS_CAPTURE,omitempty"`
-	NetworkAdapter      string              `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_NETWORK_ADAPTER_STRING,omitempty"`
-	OptimizationMethod  OptimizationMethod  `json:"servermain.CHANNEL_WRITE_OPTIMIZATIONS_METHOD,omitempty"`
-	DutyCycle           int                 `json:"servermain.CHANNEL_WRITE_OPTIMIZATIONS_DUTY_CYCLE,omitempty"`
-	FloatingPointValues FloatingPointValues `json:"servermain.CHANNEL_NON_NORMALIZED_FLOATING_POINT_HANDLING,omitempty"`
+	Name                        string               `json:"common.ALLTYPES_NAME,omitempty"`
+	Description                 string               `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID                   int64                `json:"PROJECT_ID,omitempty"`
+	Driver                      string               `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER,omitempty"`
+	DiagnosticsCapture          *bool                `json:"servermain.CHANNEL_DIAGNOSTICS_CAPTURE,omitempty"`
+	NetworkAdapter              *string              `json:"servermain.CHANNEL_ETHERNET_COMMUNICATIONS_NETWORK_ADAPTER_STRING,omitempty"`
+	OptimizationMethod          *OptimizationMethod  `json:"servermain.CHANNEL_WRITE_OPTIMIZATIONS_METHOD,omitempty"`
+	DutyCycle                   *int                 `json:"servermain.CHANNEL_WRITE_OPTIMIZATIONS_DUTY_CYCLE,omitempty"`
+	FloatingPointValues         *FloatingPointValues `json:"servermain.CHANNEL_NON_NORMALIZED_FLOATING_POINT_HANDLING,omitempty"`
+	InterDeviceDelay            *int                 `json:"servermain.CHANNEL_INTER_DEVICE_DELAY_MS,omitempty"`
+	VirtualNetwork              *VirtualNetwork      `json:"servermain.CHANNEL_COMMUNICATIONS_SERIALIZATION_VIRTUAL_NETWORK,omitempty"`
+	TransactionsPerCycle        *int                 `json:"servermain.CHANNEL_COMMUNICATIONS_SERIALIZATION_TRANSACTIONS_PER_CYCLE,omitempty"`
+	NetworkMode                 *NetworkMode         `json:"servermain.CHANNEL_COMMUNICATIONS_SERIALIZATION_NETWORK_MODE,omitempty"`
+	EncapsulationNetworkAdapter *string              `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_NETWORK_ADAPTER_STRING,omitempty"`
+	PhysicalMedium              *PhysicalMedium      `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_PHYSICAL_MEDIUM,omitempty"`
+	COMID                       *int                 `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_COM_ID,omitempty"`
+	BaudRate                    *BautRate            `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_BAUD_RATE,omitempty"`
+	DataBits                    *DataBits            `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_DATA_BITS,omitempty"`
+	Parity                      *Parity              `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_PARITY,omitempty"`
+	StopBits                    *StopBits            `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_STOP_BITS,omitempty"`
+	FlowControl                 *FlowControl         `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_FLOW_CONTROL,omitempty"`
+	RTSLineRaise                *int                 `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_RTS_LINE_RAISE_MS,omitempty"`
+	RTSLineDrop                 *int                 `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_RTS_LINE_DROP_MS,omitempty"`
+	RTSPollWithEchoSuppression  *bool                `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_RTS_LINE_POLL_WITH_ECHO_SUPPRESSION,omitempty"`
+	CloseIdleConnection         *bool                `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_CLOSE_IDLE_CONNECTION,omitempty"`
+	IdleTimeToClose             *int                 `json:"servermain.CHANNEL_SERIAL_COMMUNICATIONS_IDLE_TIME_TO_CLOSE_SECONDS,omitempty"`
+	ModemName                   *string              `json:"servermain.CHANNEL_MODEM_SETTINGS_MODEM_STRING,omitempty"`
+	ModemConnectTimeout         *int                 `json:"servermain.CHANNEL_MODEM_SETTINGS_CONNECT_TIMEOUT_SECONDS,omitempty"`
+	ModemAutoDial               *bool                `json:"servermain.CHANNEL_MODEM_SETTINGS_AUTO_DIAL,omitempty"`
+	ModemReportCommErrors       *bool                `json:"servermain.CHANNEL_MODEM_SETTINGS_REPORT_COMMUNICATION_ERRORS,omitempty"`
+	ModemCloseIdleConnection    *bool                `json:"servermain.CHANNEL_MODEM_SETTINGS_CLOSE_IDLE_CONNECTION,omitempty"`
+	ModemIdleTimeToClose        *int                 `json:"servermain.CHANNEL_MODEM_SETTINGS_IDLE_TIME_TO_CLOSE_SECONDS,omitempty"`
+	ModemReadProcessing         *ReadProcessing      `json:"servermain.CHANNEL_MODEM_SETTINGS_READ_PROCESSING,omitempty"`
+}
+
+// serialDrivers contains the names of the drivers supporting serial
+// communications, including modem and Ethernet encapsulation. Use
+// RegisterSerialDriver to add drivers missing from this list.
+var serialDrivers = struct {
+	sync.RWMutex
+	names map[string]bool
+}{names: map[string]bool{
+	"Allen-Bradley DF1":         true,
+	"Allen-Bradley DH+":         true,
+	DNP3ClientDriver:            true,
+	"GE SNP":                    true,
+	"Mitsubishi FX":             true,
+	"Mitsubishi Serial":         true,
+	"Modbus ASCII":              true,
+	"Modbus RTU Serial":         true,
+	"Modbus Unsolicited Serial": true,
+	"Omron FINS Serial":         true,
+	"Omron Host Link":           true,
+	SiemensS5AS511Driver:        true,
+	"Siemens S7 MPI":            true,
+	"Siemens S7-200":            true,
+}}
+
+// RegisterSerialDriver registers drivers supporting serial communications,
+// so serial communication settings are accepted for channels using them.
+// Only the most common serial drivers are known by default.
+func RegisterSerialDriver(names ...string) {
+	serialDrivers.Lock()
+	defer serialDrivers.Unlock()
+	for _, name := range names {
+		serialDrivers.names[name] = true
+	}
+}
+
+func isSerialDriver(name string) bool {
+	serialDrivers.RLock()
+	defer serialDrivers.RUnlock()
+	return serialDrivers.names[name]
+}
+
+// ControlLogixUnsolicitedChannel represents a ControlLogix Unsolicited channel.
+type ControlLogixUnsolicitedChannel struct {
+	Channel
+	ListenPort        int `json:"controllogix_unsolicited.CHANNEL_PORT_NUMBER"`
+	MaxConnections    int `json:"controllogix_unsolicited.CHANNEL_MAX_CONNECTIONS"`
+	InactivityTimeout int `json:"controllogix_unsolicited.CHANNEL_INACTIVITY_TIMEOUT_SECONDS"`
+}
+
+// ControlLogixUnsolicitedChannelOptions represents all ControlLogix Unsolicited channel options.
+type ControlLogixUnsolicitedChannelOptions struct {
+	ChannelOptions
+	ListenPort        *int `json:"controllogix_unsolicited.CHANNEL_PORT_NUMBER,omitempty"`
+	MaxConnections    *int `json:"controllogix_unsolicited.CHANNEL_MAX_CONNECTIONS,omitempty"`
+	InactivityTimeout *int `json:"controllogix_unsolicited.CHANNEL_INACTIVITY_TIMEOUT_SECONDS,omitempty"`
+}
+
+// DNP3ClientChannel represents a DNP3 Client channel.
+type DNP3ClientChannel struct {
+	Channel
+	Protocol          Protocol `json:"dnp3_client.CHANNEL_PROTOCOL"`
+	DestinationHost   string   `json:"dnp3_client.CHANNEL_DESTINATION_HOST"`
+	DestinationPort   int      `json:"dnp3_client.CHANNEL_DESTINATION_PORT"`
+	ListenerPort      int      `json:"dnp3_client.CHANNEL_LISTENER_PORT"`
+	SourcePort        int      `json:"dnp3_client.CHANNEL_SOURCE_PORT"`
+	ConnectTimeout    int      `json:"dnp3_client.CHANNEL_CONNECT_TIMEOUT_SECONDS"`
+	ResponseTimeout   int      `json:"dnp3_client.CHANNEL_RESPONSE_TIMEOUT_MS"`
+	LinkLayerRetries  int      `json:"dnp3_client.CHANNEL_LINK_LAYER_RETRIES"`
+	LinkConfirmations bool     `json:"dnp3_client.CHANNEL_LINK_CONFIRMATIONS"`
+}
+
+// DNP3ClientChannelOptions represents all DNP3 Client channel options.
+type DNP3ClientChannelOptions struct {
+	ChannelOptions
+	Protocol          *Protocol `json:"dnp3_client.CHANNEL_PROTOCOL,omitempty"`
+	DestinationHost   *string   `json:"dnp3_client.CHANNEL_DESTINATION_HOST,omitempty"`
+	DestinationPort   *int      `json:"dnp3_client.CHANNEL_DESTINATION_PORT,omitempty"`
+	ListenerPort      *int      `json:"dnp3_client.CHANNEL_LISTENER_PORT,omitempty"`
+	SourcePort        *int      `json:"dnp3_client.CHANNEL_SOURCE_PORT,omitempty"`
+	ConnectTimeout    *int      `json:"dnp3_client.CHANNEL_CONNECT_TIMEOUT_SECONDS,omitempty"`
+	ResponseTimeout   *int      `json:"dnp3_client.CHANNEL_RESPONSE_TIMEOUT_MS,omitempty"`
+	LinkLayerRetries  *int      `json:"dnp3_client.CHANNEL_LINK_LAYER_RETRIES,omitempty"`
+	LinkConfirmations *bool     `json:"dnp3_client.CHANNEL_LINK_CONFIRMATIONS,omitempty"`
+}
+
+// ModbusTCPChannel represents a Modbus TCP/IP Ethernet channel.
+type ModbusTCPChannel struct {
+	Channel
+	UseMultipleSockets  bool     `json:"modbus_tcpip_ethernet.CHANNEL_USE_ONE_OR_MORE_SOCKETS_PER_DEVICE"`
+	MaxSocketsPerDevice int      `json:"modbus_tcpip_ethernet.CHANNEL_MAXIMUM_SOCKETS_PER_DEVICE"`
+	UnsolicitedPort     int      `json:"modbus_tcpip_ethernet.CHANNEL_ETHERNET_PORT_NUMBER"`
+	UnsolicitedProtocol Protocol `json:"modbus_tcpip_ethernet.CHANNEL_ETHERNET_PROTOCOL"`
+}
+
+// ModbusTCPChannelOptions represents all Modbus TCP/IP Ethernet channel options.
+type ModbusTCPChannelOptions struct {
+	ChannelOptions
+	UseMultipleSockets  *bool     `json:"modbus_tcpip_ethernet.CHANNEL_USE_ONE_OR_MORE_SOCKETS_PER_DEVICE,omitempty"`
+	MaxSocketsPerDevice *int      `json:"modbus_tcpip_ethernet.CHANNEL_MAXIMUM_SOCKETS_PER_DEVICE,omitempty"`
+	UnsolicitedPort     *int      `json:"modbus_tcpip_ethernet.CHANNEL_ETHERNET_PORT_NUMBER,omitempty"`
+	UnsolicitedProtocol *Protocol `json:"modbus_tcpip_ethernet.CHANNEL_ETHERNET_PROTOCOL,omitempty"`
+}
+
+// SimulatorChannel represents a Simulator channel.
+type SimulatorChannel struct {
+	Channel
+	ItemPersistence         bool   `json:"simulator.CHANNEL_ITEM_PERSISTENCE"`
+	ItemPersistenceDataFile string `json:"simulator.CHANNEL_ITEM_PERSISTENCE_DATA_FILE"`
+}
+
+// SimulatorChannelOptions represents all Simulator channel options.
+type SimulatorChannelOptions struct {
+	ChannelOptions
+	ItemPersistence         *bool   `json:"simulator.CHANNEL_ITEM_PERSISTENCE,omitempty"`
+	ItemPersistenceDataFile *string `json:"simulator.CHANNEL_ITEM_PERSISTENCE_DATA_FILE,omitempty"`
 }
 
 // ListChannels gets a list of channels.
-func (s *ChannelService) ListChannels() ([]*Channel, error) {
-	req, err := s.client.NewRequest("GET", "channels", nil)
+func (s *ChannelService) ListChannels(options ...RequestOptionFunc) ([]*Channel, error) {
+	req, err := s.client.NewRequest("GET", "channels", nil, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -68,9 +235,46 @@ func (s *ChannelService) ListChannels() ([]*Channel, error) {
 	return cannels, nil
 }
 
-// CreateChannel creates a new channel.
-func (s *ChannelService) CreateChannel(options *ChannelOptions) error {
-	req, err := s.client.NewRequest("POST", "channels", options)
+// CreateChannel creates a new channel. Serial communication settings are
+// only accepted for drivers supporting serial communications.
+func (s *ChannelService) CreateChannel(opt *ChannelOptions, options ...RequestOptionFunc) error {
+	return s.createChannel(opt, options...)
+}
+
+// CreateChannels creates multiple channels at once. Each channel must be a
+// *ChannelOptions or the options of one of the typed channels. Large inputs
+// are created in chunks, see Client.SetBulkChunkSize. A result is returned
+// for every channel which creation was attempted.
+func (s *ChannelService) CreateChannels(channels []interface{}, options ...RequestOptionFunc) ([]*BulkResult, error) {
+	return s.client.bulkCreate("channels", channels, checkSerialSettings, options)
+}
+
+// CreateControlLogixUnsolicitedChannel creates a new ControlLogix Unsolicited channel.
+func (s *ChannelService) CreateControlLogixUnsolicitedChannel(opt *ControlLogixUnsolicitedChannelOptions, options ...RequestOptionFunc) error {
+	return s.createChannel(opt, options...)
+}
+
+// CreateDNP3ClientChannel creates a new DNP3 Client channel.
+func (s *ChannelService) CreateDNP3ClientChannel(opt *DNP3ClientChannelOptions, options ...RequestOptionFunc) error {
+	return s.createChannel(opt, options...)
+}
+
+// CreateModbusTCPChannel creates a new Modbus TCP/IP Ethernet channel.
+func (s *ChannelService) CreateModbusTCPChannel(opt *ModbusTCPChannelOptions, options ...RequestOptionFunc) error {
+	return s.createChannel(opt, options...)
+}
+
+// CreateSimulatorChannel creates a new Simulator channel.
+func (s *ChannelService) CreateSimulatorChannel(opt *SimulatorChannelOptions, options ...RequestOptionFunc) error {
+	return s.createChannel(opt, options...)
+}
+
+func (s *ChannelService) createChannel(v interface{}, options ...RequestOptionFunc) error {
+	if err := checkSerialSettings(v); err != nil {
+		return err
+	}
+
+	req, err := s.client.NewRequest("POST", "channels", v, options...)
 	if err != nil {
 		return err
 	}
@@ -78,26 +282,111 @@ func (s *ChannelService) CreateChannel(options *ChannelOptions) error {
 }
 
 // GetChannel gets a channel.
-func (s *ChannelService) GetChannel(name string) (*Channel, error) {
+func (s *ChannelService) GetChannel(name string, options ...RequestOptionFunc) (*Channel, error) {
+	c := new(Channel)
+	if err := s.getChannel(name, c, options...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetControlLogixUnsolicitedChannel gets a ControlLogix Unsolicited channel.
+func (s *ChannelService) GetControlLogixUnsolicitedChannel(name string, options ...RequestOptionFunc) (*ControlLogixUnsolicitedChannel, error) {
+	c := new(ControlLogixUnsolicitedChannel)
+	if err := s.getChannel(name, c, options...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetDNP3ClientChannel gets a DNP3 Client channel.
+func (s *ChannelService) GetDNP3ClientChannel(name string, options ...RequestOptionFunc) (*DNP3ClientChannel, error) {
+	c := new(DNP3ClientChannel)
+	if err := s.getChannel(name, c, options...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetModbusTCPChannel gets a Modbus TCP/IP Ethernet channel.
+func (s *ChannelService) GetModbusTCPChannel(name string, options ...RequestOptionFunc) (*ModbusTCPChannel, error) {
+	c := new(ModbusTCPChannel)
+	if err := s.getChannel(name, c, options...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetSimulatorChannel gets a Simulator channel.
+func (s *ChannelService) GetSimulatorChannel(name string, options ...RequestOptionFunc) (*SimulatorChannel, error) {
+	c := new(SimulatorChannel)
+	if err := s.getChannel(name, c, options...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *ChannelService) getChannel(name string, v interface{}, options ...RequestOptionFunc) error {
 	u := fmt.Sprintf("channels/%s", url.PathEscape(name))
 
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.NewRequest("GET", u, nil, options...)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Do(req, v)
+}
+
+// GetChannelStatistics gets the runtime statistics of a channel.
+func (s *ChannelService) GetChannelStatistics(name string, options ...RequestOptionFunc) (*Statistics, error) {
+	u := fmt.Sprintf("channels/%s/_statistics", url.PathEscape(name))
+
+	req, err := s.client.NewRequest("GET", u, nil, options...)
 	if err != nil {
 		return nil, err
 	}
 
-	var c *Channel
-	if err = s.client.Do(req, c); err != nil {
+	stats := new(Statistics)
+	if err = s.client.Do(req, stats); err != nil {
 		return nil, err
 	}
 
-	return c, nil
+	return stats, nil
+}
+
+// UpdateChannel updates an existing channel. Serial communication settings
+// are only accepted for drivers supporting serial communications.
+func (s *ChannelService) UpdateChannel(name string, opt *ChannelOptions, options ...RequestOptionFunc) error {
+	return s.updateChannel(name, opt, options...)
 }
 
-// UpdateChannel updates an existing channel.
-func (s *ChannelService) UpdateChannel(name string, options *ChannelOptions) error {
+// UpdateControlLogixUnsolicitedChannel updates an existing ControlLogix Unsolicited channel.
+func (s *ChannelService) UpdateControlLogixUnsolicitedChannel(name string, opt *ControlLogixUnsolicitedChannelOptions, options ...RequestOptionFunc) error {
+	return s.updateChannel(name, opt, options...)
+}
+
+// UpdateDNP3ClientChannel updates an existing DNP3 Client channel.
+func (s *ChannelService) UpdateDNP3ClientChannel(name string, opt *DNP3ClientChannelOptions, options ...RequestOptionFunc) error {
+	return s.updateChannel(name, opt, options...)
+}
+
+// UpdateModbusTCPChannel updates an existing Modbus TCP/IP Ethernet channel.
+func (s *ChannelService) UpdateModbusTCPChannel(name string, opt *ModbusTCPChannelOptions, options ...RequestOptionFunc) error {
+	return s.updateChannel(name, opt, options...)
+}
+
+// UpdateSimulatorChannel updates an existing Simulator channel.
+func (s *ChannelService) UpdateSimulatorChannel(name string, opt *SimulatorChannelOptions, options ...RequestOptionFunc) error {
+	return s.updateChannel(name, opt, options...)
+}
+
+func (s *ChannelService) updateChannel(name string, v interface{}, options ...RequestOptionFunc) error {
+	if err := checkSerialSettings(v); err != nil {
+		return err
+	}
+
 	u := fmt.Sprintf("channels/%s", url.PathEscape(name))
-	req, err := s.client.NewRequest("PUT", u, options)
+	req, err := s.client.NewRequest("PUT", u, v, options...)
 	if err != nil {
 		return err
 	}
@@ -105,11 +394,95 @@ func (s *ChannelService) UpdateChannel(name string, options *ChannelOptions) err
 }
 
 // DeleteChannel deletes a channel.
-func (s *ChannelService) DeleteChannel(name string) error {
+func (s *ChannelService) DeleteChannel(name string, options ...RequestOptionFunc) error {
 	u := fmt.Sprintf("channels/%s", url.PathEscape(name))
-	req, err := s.client.NewRequest("DELETE", u, nil)
+	req, err := s.client.NewRequest("DELETE", u, nil, options...)
 	if err != nil {
 		return err
 	}
 	return s.client.Do(req, nil)
 }
+
+// checkSerialSettings returns an error when serial communication settings
+// are set for a driver that does not support serial communications. When
+// the options do not contain the driver, validation is left to the server.
+func checkSerialSettings(v interface{}) error {
+	opt, ok := v.(channelSettings)
+	if !ok {
+		return nil
+	}
+	o := opt.channelOptions()
+	if o == nil || o.Driver == "" || !o.hasSerialSettings() {
+		return nil
+	}
+
+	if !isSerialDriver(o.Driver) {
+		return fmt.Errorf("driver %q does not support serial communications, "+
+			"use RegisterSerialDriver if it does", o.Driver)
+	}
+
+	return nil
+}
+
+// channelSettings is implemented by ChannelOptions and all driver specific
+// channel options embedding it.
+type channelSettings interface {
+	// channelOptions returns the (embedded) channel options, or nil if
+	// the options are nil.
+	channelOptions() *ChannelOptions
+}
+
+func (o *ChannelOptions) channelOptions() *ChannelOptions {
+	return o
+}
+
+func (o *ControlLogixUnsolicitedChannelOptions) channelOptions() *ChannelOptions {
+	if o == nil {
+		return nil
+	}
+	return &o.ChannelOptions
+}
+
+func (o *DNP3ClientChannelOptions) channelOptions() *ChannelOptions {
+	if o == nil {
+		return nil
+	}
+	return &o.ChannelOptions
+}
+
+func (o *ModbusTCPChannelOptions) channelOptions() *ChannelOptions {
+	if o == nil {
+		return nil
+	}
+	return &o.ChannelOptions
+}
+
+func (o *SimulatorChannelOptions) channelOptions() *ChannelOptions {
+	if o == nil {
+		return nil
+	}
+	return &o.ChannelOptions
+}
+
+func (o *ChannelOptions) hasSerialSettings() bool {
+	return o.EncapsulationNetworkAdapter != nil ||
+		o.PhysicalMedium != nil ||
+		o.COMID != nil ||
+		o.BaudRate != nil ||
+		o.DataBits != nil ||
+		o.Parity != nil ||
+		o.StopBits != nil ||
+		o.FlowControl != nil ||
+		o.RTSLineRaise != nil ||
+		o.RTSLineDrop != nil ||
+		o.RTSPollWithEchoSuppression != nil ||
+		o.CloseIdleConnection != nil ||
+		o.IdleTimeToClose != nil ||
+		o.ModemName != nil ||
+		o.ModemConnectTimeout != nil ||
+		o.ModemAutoDial != nil ||
+		o.ModemReportCommErrors != nil ||
+		o.ModemCloseIdleConnection != nil ||
+		o.ModemIdleTimeToClose != nil ||
+		o.ModemReadProcessing != nil
+}
@@ -0,0 +1,84 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCheckSerialSettings(t *testing.T) {
+	RegisterSerialDriver("Custom Serial")
+
+	tests := []struct {
+		name    string
+		opt     interface{}
+		wantErr bool
+	}{
+		{"nil options", (*ChannelOptions)(nil), false},
+		{"nil typed options", (*SimulatorChannelOptions)(nil), false},
+		{"no serial settings", &ChannelOptions{Driver: SimulatorDriver}, false},
+		{"serial driver", &ChannelOptions{Driver: "Modbus RTU Serial", COMID: Int(1)}, false},
+		{"registered driver", &ChannelOptions{Driver: "Custom Serial", COMID: Int(1)}, false},
+		{"no driver", &ChannelOptions{COMID: Int(1)}, false},
+		{"non-serial driver", &ChannelOptions{Driver: SimulatorDriver, COMID: Int(1)}, true},
+		{"typed non-serial driver", &ModbusTCPChannelOptions{ChannelOptions: ChannelOptions{
+			Driver: ModbusTCPDriver, ModemName: String("Modem1")}}, true},
+		{"typed serial driver", &DNP3ClientChannelOptions{ChannelOptions: ChannelOptions{
+			Driver: DNP3ClientDriver, COMID: Int(1)}}, false},
+		{"generic properties", Properties{"servermain.CHANNEL_SERIAL_COMMUNICATIONS_COM_ID": 1}, false},
+	}
+
+	for _, tt := range tests {
+		err := checkSerialSettings(tt.opt)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: checkSerialSettings returned error %v, want error %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestUpdateChannelSerialSettings(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	var methods []string
+	mux.HandleFunc("/config/v1/project/channels/Channel1", func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+	})
+
+	// Without a driver in the options, the settings are validated by the
+	// server, so the channel must not be read first.
+	if err := client.Channels.UpdateChannel("Channel1", &ChannelOptions{COMID: Int(2)}); err != nil {
+		t.Fatalf("UpdateChannel returned error: %v", err)
+	}
+	if got := strings.Join(methods, ","); got != "PUT" {
+		t.Errorf("UpdateChannel sent %s requests, want a single PUT", got)
+	}
+
+	methods = nil
+	err := client.Channels.UpdateChannel("Channel1", &ChannelOptions{
+		Driver: SimulatorDriver,
+		COMID:  Int(2),
+	})
+	if err == nil {
+		t.Errorf("UpdateChannel returned no error for a non-serial driver")
+	}
+	if len(methods) != 0 {
+		t.Errorf("UpdateChannel sent %v requests, want none", methods)
+	}
+}
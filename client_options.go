@@ -0,0 +1,333 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default ports of the KEPServerEX Configuration API.
+const (
+	DefaultHTTPPort  = 57412
+	DefaultHTTPSPort = 57512
+)
+
+// ClientOptionFunc can be passed to NewClientWithOptions to customize the
+// client.
+type ClientOptionFunc func(*Client) error
+
+// clientConfig holds the settings used while creating a client, which are
+// applied after all client options are applied.
+type clientConfig struct {
+	scheme string
+	port   int
+
+	rootCAs             *x509.CertPool
+	fingerprint         []byte
+	insecure            bool
+	proxy               func(*http.Request) (*url.URL, error)
+	timeout             time.Duration
+	dialTimeout         time.Duration
+	tlsHandshakeTimeout time.Duration
+}
+
+// NewClientWithOptions returns a new KEPServerEX API client for the given host,
+// which may include a port. By default the client connects using HTTPS and
+// http.DefaultClient. To use API methods which require authentication,
+// provide a valid username and password.
+func NewClientWithOptions(host, username, password string, options ...ClientOptionFunc) (*Client, error) {
+	c := &Client{
+		client:        http.DefaultClient,
+		username:      username,
+		password:      password,
+		userAgent:     userAgent,
+		bulkChunkSize: defaultBulkChunkSize,
+		config:        &clientConfig{scheme: "https"},
+	}
+
+	for _, fn := range options {
+		if fn == nil {
+			continue
+		}
+		if err := fn(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.configure(host); err != nil {
+		return nil, err
+	}
+	c.config = nil
+
+	// Create all the public services.
+	c.Channels = &ChannelService{client: c}
+	c.Devices = &DeviceService{client: c}
+	c.EventLog = &EventLogService{client: c}
+	c.TagGroups = &TagGroupService{client: c}
+	c.Tags = &TagService{client: c}
+	c.TransactionLog = &TransactionLogService{client: c}
+
+	return c, nil
+}
+
+// configure sets the base URL and HTTP client of the client, using the
+// settings of the applied client options.
+func (c *Client) configure(host string) error {
+	cfg := c.config
+
+	if cfg.port != 0 {
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		host = net.JoinHostPort(strings.Trim(host, "[]"), strconv.Itoa(cfg.port))
+	}
+	if err := c.SetBaseURL(cfg.scheme + "://" + host); err != nil {
+		return err
+	}
+
+	custom := cfg.rootCAs != nil || cfg.fingerprint != nil || cfg.insecure ||
+		cfg.proxy != nil || cfg.dialTimeout > 0 || cfg.tlsHandshakeTimeout > 0
+	if !custom && cfg.timeout == 0 {
+		return nil
+	}
+
+	// Copy the HTTP client, so the given (or default) client is not changed.
+	httpClient := *c.client
+	if cfg.timeout > 0 {
+		httpClient.Timeout = cfg.timeout
+	}
+
+	if custom {
+		base := httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		t, ok := base.(*http.Transport)
+		if !ok {
+			return errors.New("transport options require the transport of the HTTP client to be a *http.Transport")
+		}
+		transport := t.Clone()
+		if err := cfg.configureTransport(transport); err != nil {
+			return err
+		}
+		httpClient.Transport = transport
+	}
+	c.client = &httpClient
+
+	return nil
+}
+
+func (cfg *clientConfig) configureTransport(t *http.Transport) error {
+	if cfg.proxy != nil {
+		t.Proxy = cfg.proxy
+	}
+	if cfg.dialTimeout > 0 {
+		t.DialContext = (&net.Dialer{
+			Timeout:   cfg.dialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+	}
+	if cfg.tlsHandshakeTimeout > 0 {
+		t.TLSHandshakeTimeout = cfg.tlsHandshakeTimeout
+	}
+
+	if cfg.rootCAs == nil && cfg.fingerprint == nil && !cfg.insecure {
+		return nil
+	}
+
+	tlsConfig := &tls.Config{}
+	if t.TLSClientConfig != nil {
+		tlsConfig = t.TLSClientConfig.Clone()
+	}
+	if cfg.rootCAs != nil {
+		tlsConfig.RootCAs = cfg.rootCAs
+	}
+	if cfg.insecure {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	if cfg.fingerprint != nil {
+		// The pinned certificate is trusted instead of verifying the chain,
+		// as KEPServerEX uses a self-signed certificate by default.
+		fingerprint := cfg.fingerprint
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.New("kepserverex: server did not present a certificate")
+			}
+			sum := sha256.Sum256(rawCerts[0])
+			if !bytes.Equal(sum[:], fingerprint) {
+				return fmt.Errorf("kepserverex: server certificate fingerprint %X does not match the pinned fingerprint", sum)
+			}
+			return nil
+		}
+	}
+	t.TLSClientConfig = tlsConfig
+
+	return nil
+}
+
+// WithHTTPClient can be used to configure a custom HTTP client. Other client
+// options are applied to a copy of the given client.
+func WithHTTPClient(httpClient *http.Client) ClientOptionFunc {
+	return func(c *Client) error {
+		if httpClient != nil {
+			c.client = httpClient
+		}
+		return nil
+	}
+}
+
+// WithScheme sets the scheme used to connect, either http or https (the
+// default).
+func WithScheme(scheme string) ClientOptionFunc {
+	return func(c *Client) error {
+		scheme = strings.ToLower(scheme)
+		if scheme != "http" && scheme != "https" {
+			return fmt.Errorf("invalid scheme %q, expected http or https", scheme)
+		}
+		c.config.scheme = scheme
+		return nil
+	}
+}
+
+// WithPort sets the port used to connect, overriding any port included in
+// the host. See DefaultHTTPPort and DefaultHTTPSPort.
+func WithPort(port int) ClientOptionFunc {
+	return func(c *Client) error {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("invalid port %d", port)
+		}
+		c.config.port = port
+		return nil
+	}
+}
+
+// WithRootCAs sets the certificate authorities used to verify the server
+// certificate, instead of the system's root CAs.
+func WithRootCAs(pool *x509.CertPool) ClientOptionFunc {
+	return func(c *Client) error {
+		c.config.rootCAs = pool
+		return nil
+	}
+}
+
+// WithCertificateFingerprint pins the server certificate by its SHA-256
+// fingerprint, given as hex string optionally separated by colons or spaces.
+// Only a server presenting the pinned certificate is trusted, which makes it
+// possible to securely use the self-signed KEPServerEX certificate.
+func WithCertificateFingerprint(fingerprint string) ClientOptionFunc {
+	return func(c *Client) error {
+		clean := strings.NewReplacer(":", "", " ", "").Replace(fingerprint)
+		sum, err := hex.DecodeString(clean)
+		if err != nil || len(sum) != sha256.Size {
+			return fmt.Errorf("invalid SHA-256 certificate fingerprint %q", fingerprint)
+		}
+		c.config.fingerprint = sum
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of the server
+// certificate. This should only be used for testing.
+func WithInsecureSkipVerify() ClientOptionFunc {
+	return func(c *Client) error {
+		c.config.insecure = true
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit for requests, including reading the
+// response body.
+func WithTimeout(timeout time.Duration) ClientOptionFunc {
+	return func(c *Client) error {
+		c.config.timeout = timeout
+		return nil
+	}
+}
+
+// WithDialTimeout sets the maximum amount of time a dial will wait for a
+// connect to complete.
+func WithDialTimeout(timeout time.Duration) ClientOptionFunc {
+	return func(c *Client) error {
+		c.config.dialTimeout = timeout
+		return nil
+	}
+}
+
+// WithTLSHandshakeTimeout sets the maximum amount of time to wait for a TLS
+// handshake.
+func WithTLSHandshakeTimeout(timeout time.Duration) ClientOptionFunc {
+	return func(c *Client) error {
+		c.config.tlsHandshakeTimeout = timeout
+		return nil
+	}
+}
+
+// WithProxy sets the function returning the proxy to use for a request,
+// e.g. http.ProxyURL. By default the proxy of the environment is used.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) ClientOptionFunc {
+	return func(c *Client) error {
+		c.config.proxy = proxy
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) ClientOptionFunc {
+	return func(c *Client) error {
+		c.userAgent = ua
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry failed requests. Retries are
+// disabled by default, use DefaultRetryPolicy to enable them.
+func WithRetryPolicy(policy *RetryPolicy) ClientOptionFunc {
+	return func(c *Client) error {
+		c.SetRetryPolicy(policy)
+		return nil
+	}
+}
+
+// WithLimiter sets the limiter used to limit the requests made by the client.
+func WithLimiter(l *Limiter) ClientOptionFunc {
+	return func(c *Client) error {
+		c.SetLimiter(l)
+		return nil
+	}
+}
+
+// WithBulkChunkSize sets the maximum number of objects created with a single
+// request when creating objects in bulk.
+func WithBulkChunkSize(size int) ClientOptionFunc {
+	return func(c *Client) error {
+		c.SetBulkChunkSize(size)
+		return nil
+	}
+}
@@ -0,0 +1,178 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTLSServer returns a TLS test server answering channel list requests,
+// and the SHA-256 fingerprint of its certificate.
+func newTLSServer() (*httptest.Server, string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/config/v1/project/channels", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `[]`)
+	})
+	server := httptest.NewTLSServer(mux)
+
+	sum := sha256.Sum256(server.Certificate().Raw)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+
+	return server, strings.Join(parts, ":")
+}
+
+func TestWithCertificateFingerprint(t *testing.T) {
+	server, fingerprint := newTLSServer()
+	defer server.Close()
+
+	host := server.Listener.Addr().String()
+
+	// The self-signed certificate is not trusted by default.
+	c, err := NewClientWithOptions(host, "user", "secret")
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+	if _, err := c.Channels.ListChannels(); err == nil {
+		t.Fatal("ListChannels returned no error for an untrusted certificate")
+	}
+
+	// Pinning the certificate makes it trusted.
+	c, err = NewClientWithOptions(host, "user", "secret", WithCertificateFingerprint(fingerprint))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+	if _, err := c.Channels.ListChannels(); err != nil {
+		t.Fatalf("ListChannels returned error for the pinned certificate: %v", err)
+	}
+
+	// The fingerprint may also be given without separators and in lower case.
+	plain := strings.ToLower(strings.Replace(fingerprint, ":", "", -1))
+	c, err = NewClientWithOptions(host, "user", "secret", WithCertificateFingerprint(plain))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+	if _, err := c.Channels.ListChannels(); err != nil {
+		t.Fatalf("ListChannels returned error for the pinned certificate: %v", err)
+	}
+
+	// A different certificate is rejected.
+	other := strings.Repeat("00:", sha256.Size-1) + "00"
+	c, err = NewClientWithOptions(host, "user", "secret", WithCertificateFingerprint(other))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+	_, err = c.Channels.ListChannels()
+	if err == nil || !strings.Contains(err.Error(), "does not match the pinned fingerprint") {
+		t.Fatalf("ListChannels returned error %v, want a fingerprint mismatch", err)
+	}
+}
+
+func TestWithCertificateFingerprintInvalid(t *testing.T) {
+	for _, fingerprint := range []string{"", "zz", "AB:CD", strings.Repeat("AB", sha256.Size+1)} {
+		if _, err := NewClientWithOptions("localhost", "user", "secret", WithCertificateFingerprint(fingerprint)); err == nil {
+			t.Errorf("NewClientWithOptions returned no error for fingerprint %q", fingerprint)
+		}
+	}
+}
+
+func TestWithRootCAs(t *testing.T) {
+	server, _ := newTLSServer()
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	c, err := NewClientWithOptions(server.Listener.Addr().String(), "user", "secret", WithRootCAs(pool))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+	if _, err := c.Channels.ListChannels(); err != nil {
+		t.Fatalf("ListChannels returned error: %v", err)
+	}
+}
+
+func TestConfigureDoesNotChangeHTTPClient(t *testing.T) {
+	httpClient := &http.Client{}
+
+	c, err := NewClientWithOptions("localhost", "user", "secret",
+		WithHTTPClient(httpClient), WithInsecureSkipVerify())
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+	if httpClient.Transport != nil {
+		t.Error("the given HTTP client was changed")
+	}
+
+	transport, ok := c.client.Transport.(*http.Transport)
+	if !ok || !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("client transport does not skip verification")
+	}
+
+	// Transport options can only be applied to a *http.Transport.
+	httpClient = &http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)}
+	if _, err := NewClientWithOptions("localhost", "user", "secret",
+		WithHTTPClient(httpClient), WithInsecureSkipVerify()); err == nil {
+		t.Error("NewClientWithOptions returned no error for a custom transport")
+	}
+}
+
+func TestSchemeAndPort(t *testing.T) {
+	tests := []struct {
+		host    string
+		options []ClientOptionFunc
+		want    string
+	}{
+		{"kepware", nil, "https://kepware/config/v1/project/"},
+		{"kepware:57512", nil, "https://kepware:57512/config/v1/project/"},
+		{"kepware", []ClientOptionFunc{WithScheme("HTTP"), WithPort(DefaultHTTPPort)}, "http://kepware:57412/config/v1/project/"},
+		{"kepware:1234", []ClientOptionFunc{WithPort(DefaultHTTPSPort)}, "https://kepware:57512/config/v1/project/"},
+		{"[::1]", []ClientOptionFunc{WithPort(DefaultHTTPSPort)}, "https://[::1]:57512/config/v1/project/"},
+	}
+
+	for _, tt := range tests {
+		c, err := NewClientWithOptions(tt.host, "user", "secret", tt.options...)
+		if err != nil {
+			t.Fatalf("NewClientWithOptions returned error: %v", err)
+		}
+		if got := c.BaseURL().String(); got != tt.want {
+			t.Errorf("base URL for %s is %s, want %s", tt.host, got, tt.want)
+		}
+	}
+
+	if _, err := NewClientWithOptions("kepware", "user", "secret", WithScheme("ftp")); err == nil {
+		t.Error("NewClientWithOptions returned no error for an invalid scheme")
+	}
+	if _, err := NewClientWithOptions("kepware", "user", "secret", WithPort(0)); err == nil {
+		t.Error("NewClientWithOptions returned no error for an invalid port")
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
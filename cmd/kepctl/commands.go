@@ -0,0 +1,349 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+
+	kepserverex "github.com/svanharmelen/go-kepserverex"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// command executes the actions for all resources.
+type command struct {
+	client *kepserverex.Client
+	out    *output
+	file   string
+	stdin  io.Reader
+}
+
+func (c *command) channels(action string, args []string) error {
+	switch action {
+	case "list":
+		if err := expectArgs(args, 0); err != nil {
+			return err
+		}
+		channels, err := c.client.Channels.ListChannels()
+		if err != nil {
+			return err
+		}
+		rows := make([][]string, 0, len(channels))
+		for _, ch := range channels {
+			rows = append(rows, []string{ch.Name, ch.Driver, ch.Description})
+		}
+		return c.out.list(channels, []string{"NAME", "DRIVER", "DESCRIPTION"}, rows)
+
+	case "get":
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		ch, err := c.client.Channels.GetChannel(args[0])
+		if err != nil {
+			return err
+		}
+		return c.out.object(ch)
+
+	case "create":
+		if err := expectArgs(args, 0); err != nil {
+			return err
+		}
+		opt := new(kepserverex.ChannelOptions)
+		if err := c.readFile(opt); err != nil {
+			return err
+		}
+		return c.client.Channels.CreateChannel(opt)
+
+	case "update":
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		opt := new(kepserverex.ChannelOptions)
+		if err := c.readFile(opt); err != nil {
+			return err
+		}
+		return c.client.Channels.UpdateChannel(args[0], opt)
+
+	case "delete":
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		return c.client.Channels.DeleteChannel(args[0])
+	}
+
+	return fmt.Errorf("unknown action %q for channels", action)
+}
+
+func (c *command) devices(action string, args []string) error {
+	switch action {
+	case "list":
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		devices, err := c.client.Devices.ListDevices(args[0])
+		if err != nil {
+			return err
+		}
+		rows := make([][]string, 0, len(devices))
+		for _, d := range devices {
+			rows = append(rows, []string{d.Name, d.Driver, d.Description})
+		}
+		return c.out.list(devices, []string{"NAME", "DRIVER", "DESCRIPTION"}, rows)
+
+	case "get":
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		channel, device, err := parseDevicePath(args[0])
+		if err != nil {
+			return err
+		}
+		d, err := c.client.Devices.GetGenericDevice(channel, device)
+		if err != nil {
+			return err
+		}
+		return c.out.object(d)
+
+	case "create":
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		var d kepserverex.GenericDevice
+		if err := c.readFile(&d); err != nil {
+			return err
+		}
+		return c.client.Devices.CreateGenericDevice(args[0], d)
+
+	case "update":
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		channel, device, err := parseDevicePath(args[0])
+		if err != nil {
+			return err
+		}
+		var d kepserverex.GenericDevice
+		if err := c.readFile(&d); err != nil {
+			return err
+		}
+		return c.client.Devices.UpdateGenericDevice(channel, device, d)
+
+	case "delete":
+		if err := expectArgs(args, 1); err != nil {
+			return err
+		}
+		channel, device, err := parseDevicePath(args[0])
+		if err != nil {
+			return err
+		}
+		return c.client.Devices.DeleteDevice(channel, device)
+	}
+
+	return fmt.Errorf("unknown action %q for devices", action)
+}
+
+func (c *command) tagGroups(action string, args []string) error {
+	if err := expectArgs(args, 1); err != nil {
+		return err
+	}
+
+	switch action {
+	case "list":
+		path, err := kepserverex.ParseTagPath(args[0])
+		if err != nil {
+			return err
+		}
+		groups, err := c.client.TagGroups.ListTagGroupsByPath(path)
+		if err != nil {
+			return err
+		}
+		rows := make([][]string, 0, len(groups))
+		for _, g := range groups {
+			rows = append(rows, []string{
+				g.Name,
+				strconv.Itoa(g.LocalTagCount),
+				strconv.Itoa(g.TotalTagCount),
+				g.Description,
+			})
+		}
+		return c.out.list(groups, []string{"NAME", "TAGS", "TOTAL TAGS", "DESCRIPTION"}, rows)
+
+	case "get":
+		path, name, err := kepserverex.ParseTagName(args[0])
+		if err != nil {
+			return err
+		}
+		group, err := c.client.TagGroups.GetTagGroupByPath(path, name)
+		if err != nil {
+			return err
+		}
+		return c.out.object(group)
+
+	case "create":
+		path, err := kepserverex.ParseTagPath(args[0])
+		if err != nil {
+			return err
+		}
+		opt := new(kepserverex.TagGroupOptions)
+		if err := c.readFile(opt); err != nil {
+			return err
+		}
+		return c.client.TagGroups.CreateTagGroupByPath(path, opt)
+
+	case "update":
+		path, name, err := kepserverex.ParseTagName(args[0])
+		if err != nil {
+			return err
+		}
+		opt := new(kepserverex.TagGroupOptions)
+		if err := c.readFile(opt); err != nil {
+			return err
+		}
+		return c.client.TagGroups.UpdateTagGroupByPath(path, name, opt)
+
+	case "delete":
+		path, name, err := kepserverex.ParseTagName(args[0])
+		if err != nil {
+			return err
+		}
+		return c.client.TagGroups.DeleteTagGroupByPath(path, name)
+	}
+
+	return fmt.Errorf("unknown action %q for tag groups", action)
+}
+
+func (c *command) tags(action string, args []string) error {
+	if err := expectArgs(args, 1); err != nil {
+		return err
+	}
+
+	switch action {
+	case "list":
+		path, err := kepserverex.ParseTagPath(args[0])
+		if err != nil {
+			return err
+		}
+		tags, err := c.client.Tags.ListTagsByPath(path)
+		if err != nil {
+			return err
+		}
+		rows := make([][]string, 0, len(tags))
+		for _, t := range tags {
+			rows = append(rows, []string{
+				t.Name,
+				t.Address,
+				strconv.Itoa(int(t.DataType)),
+				strconv.Itoa(int(t.ClientAccess)),
+				strconv.Itoa(t.ScanRate),
+				t.Description,
+			})
+		}
+		return c.out.list(tags, []string{"NAME", "ADDRESS", "DATA TYPE", "ACCESS", "SCAN RATE", "DESCRIPTION"}, rows)
+
+	case "get":
+		path, name, err := kepserverex.ParseTagName(args[0])
+		if err != nil {
+			return err
+		}
+		tag, err := c.client.Tags.GetTagByPath(path, name)
+		if err != nil {
+			return err
+		}
+		return c.out.object(tag)
+
+	case "create":
+		path, err := kepserverex.ParseTagPath(args[0])
+		if err != nil {
+			return err
+		}
+		opt := new(kepserverex.TagOptions)
+		if err := c.readFile(opt); err != nil {
+			return err
+		}
+		return c.client.Tags.CreateTagByPath(path, opt)
+
+	case "update":
+		path, name, err := kepserverex.ParseTagName(args[0])
+		if err != nil {
+			return err
+		}
+		opt := new(kepserverex.TagOptions)
+		if err := c.readFile(opt); err != nil {
+			return err
+		}
+		return c.client.Tags.UpdateTagByPath(path, name, opt)
+
+	case "delete":
+		path, name, err := kepserverex.ParseTagName(args[0])
+		if err != nil {
+			return err
+		}
+		return c.client.Tags.DeleteTagByPath(path, name)
+	}
+
+	return fmt.Errorf("unknown action %q for tags", action)
+}
+
+// readFile decodes the JSON or YAML file given with -f into v.
+func (c *command) readFile(v interface{}) error {
+	var data []byte
+	var err error
+
+	switch c.file {
+	case "":
+		return errors.New("missing object properties, use -f to pass a file")
+	case "-":
+		data, err = ioutil.ReadAll(c.stdin)
+	default:
+		data, err = ioutil.ReadFile(c.file)
+	}
+	if err != nil {
+		return err
+	}
+
+	// YAML is a superset of JSON, so both can be decoded as YAML.
+	var generic interface{}
+	if err := yaml.Unmarshal(data, &generic); err != nil {
+		return fmt.Errorf("failed to parse %s: %v", c.file, err)
+	}
+
+	data, err = json.Marshal(jsonCompatible(generic))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func parseDevicePath(s string) (string, string, error) {
+	path, err := kepserverex.ParseTagPath(s)
+	if err != nil || len(path.Groups) > 0 {
+		return "", "", fmt.Errorf("invalid device %q: expected CHANNEL.DEVICE", s)
+	}
+	return path.Channel, path.Device, nil
+}
+
+func expectArgs(args []string, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("expected %d argument(s), got %d", n, len(args))
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	kepserverex "github.com/svanharmelen/go-kepserverex"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// config represents the config file containing the server profiles.
+type config struct {
+	// Default is the name of the profile used when no profile is given.
+	Default  string              `yaml:"default"`
+	Profiles map[string]*profile `yaml:"profiles"`
+}
+
+// profile represents the connection settings of a single server.
+type profile struct {
+	URL      string `yaml:"url"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	Insecure bool   `yaml:"insecure"`
+}
+
+// loadConfig returns the connection settings to use, combining the
+// profile from the config file, the environment and the flags.
+func loadConfig(f *flags) (*profile, error) {
+	path := firstNonEmpty(f.config, os.Getenv("KEPCTL_CONFIG"))
+	if path == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, ".kepctl.yaml")
+		}
+	}
+
+	cfg := &config{}
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := yaml.Unmarshal(data, cfg); err != nil {
+				return nil, fmt.Errorf("failed to parse config file %s: %v", path, err)
+			}
+		case !os.IsNotExist(err) || f.config != "":
+			return nil, err
+		}
+	}
+
+	p := &profile{}
+	name := firstNonEmpty(f.profile, os.Getenv("KEPCTL_PROFILE"), cfg.Default)
+	if name != "" {
+		found, ok := cfg.Profiles[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown profile %q", name)
+		}
+		*p = *found
+	}
+
+	p.URL = firstNonEmpty(f.url, os.Getenv("KEPCTL_URL"), p.URL)
+	p.Username = firstNonEmpty(f.username, os.Getenv("KEPCTL_USERNAME"), p.Username)
+	p.Password = firstNonEmpty(f.password, os.Getenv("KEPCTL_PASSWORD"), p.Password)
+
+	if v := os.Getenv("KEPCTL_INSECURE"); v != "" {
+		insecure, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for KEPCTL_INSECURE: %v", err)
+		}
+		p.Insecure = insecure
+	}
+	if f.insecure {
+		p.Insecure = true
+	}
+
+	if p.URL == "" {
+		return nil, fmt.Errorf("no server configured, use -url, KEPCTL_URL or a profile")
+	}
+
+	return p, nil
+}
+
+// newClient returns a new client for the profile. The URL of the profile
+// may be a full URL or just a host[:port], in which case HTTPS is used.
+func (p *profile) newClient() (*kepserverex.Client, error) {
+	rawURL := p.URL
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server URL %q: %v", p.URL, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid server URL %q: missing host", p.URL)
+	}
+	switch strings.TrimSuffix(u.Path, "/") {
+	case "", "/config/v1", "/config/v1/project":
+	default:
+		return nil, fmt.Errorf("invalid server URL %q: unexpected path %q", p.URL, u.Path)
+	}
+
+	options := []kepserverex.ClientOptionFunc{
+		kepserverex.WithHTTPClient(&http.Client{}),
+		kepserverex.WithScheme(u.Scheme),
+	}
+	if p.Insecure {
+		options = append(options, kepserverex.WithInsecureSkipVerify())
+	}
+
+	return kepserverex.NewClientWithOptions(u.Host, p.Username, p.Password, options...)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,166 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Command kepctl is a command-line tool for managing KEPServerEX projects
+// through the KEPServerEX Configuration API.
+//
+// Usage:
+//
+//	kepctl [flags] <resource> <action> [arguments]
+//
+// The resources are channels, devices, tag-groups and tags, and the actions
+// are list, get, create, update and delete. Devices, tag groups and tags are
+// addressed using dotted paths, e.g. Channel1.Device1.Group1.Tag1.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const usage = `Usage: kepctl [flags] <resource> <action> [arguments]
+
+Resources and actions:
+  channels   list | get NAME | create -f FILE | update NAME -f FILE | delete NAME
+  devices    list CHANNEL | get CHANNEL.DEVICE | create CHANNEL -f FILE
+             update CHANNEL.DEVICE -f FILE | delete CHANNEL.DEVICE
+  tag-groups list PATH | get PATH.GROUP | create PATH -f FILE
+             update PATH.GROUP -f FILE | delete PATH.GROUP
+  tags       list PATH | get PATH.TAG | create PATH -f FILE
+             update PATH.TAG -f FILE | delete PATH.TAG
+
+A PATH is a dotted path to a device or (nested) tag group, for example
+Channel1.Device1 or Channel1.Device1.Group1.SubGroup1. Files passed with -f
+contain the object properties as JSON or YAML, use - to read from stdin.
+
+Connection settings are read from the flags, the KEPCTL_URL, KEPCTL_USERNAME,
+KEPCTL_PASSWORD, KEPCTL_INSECURE and KEPCTL_PROFILE environment variables or a
+named profile in the config file (default ~/.kepctl.yaml), in that order.
+
+Flags:
+`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "kepctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// flags contains all parsed command-line flags.
+type flags struct {
+	config   string
+	profile  string
+	url      string
+	username string
+	password string
+	insecure bool
+	output   string
+	file     string
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	var f flags
+
+	fs := flag.NewFlagSet("kepctl", flag.ContinueOnError)
+	fs.StringVar(&f.config, "config", "", "path of the config file")
+	fs.StringVar(&f.profile, "profile", "", "name of the server profile to use")
+	fs.StringVar(&f.url, "url", "", "URL or host[:port] of the KEPServerEX server")
+	fs.StringVar(&f.username, "username", "", "username used to authenticate")
+	fs.StringVar(&f.password, "password", "", "password used to authenticate")
+	fs.BoolVar(&f.insecure, "insecure", false, "skip verification of the server certificate")
+	fs.StringVar(&f.output, "o", "table", "output format: table, json or yaml")
+	fs.StringVar(&f.file, "f", "", "JSON or YAML file with object properties (- for stdin)")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+	if len(positional) < 2 {
+		fs.Usage()
+		return errors.New("missing resource or action")
+	}
+
+	out, err := newOutput(stdout, f.output)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(&f)
+	if err != nil {
+		return err
+	}
+
+	client, err := cfg.newClient()
+	if err != nil {
+		return err
+	}
+
+	cmd := &command{
+		client: client,
+		out:    out,
+		file:   f.file,
+		stdin:  stdin,
+	}
+
+	resource, action, rest := positional[0], positional[1], positional[2:]
+	switch strings.ToLower(resource) {
+	case "channel", "channels":
+		return cmd.channels(action, rest)
+	case "device", "devices":
+		return cmd.devices(action, rest)
+	case "tag-group", "tag-groups", "taggroup", "taggroups":
+		return cmd.tagGroups(action, rest)
+	case "tag", "tags":
+		return cmd.tags(action, rest)
+	}
+
+	return fmt.Errorf("unknown resource %q", resource)
+}
+
+// parseInterspersed parses the flags in args, allowing flags and positional
+// arguments to be mixed, and returns the positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+
+		// The flag package consumes the "--" terminating the flags, after
+		// which all remaining arguments are positional.
+		if n := len(args) - len(rest); n > 0 && args[n-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
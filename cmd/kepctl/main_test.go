@@ -0,0 +1,424 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	kepserverex "github.com/svanharmelen/go-kepserverex"
+	"github.com/svanharmelen/go-kepserverex/kepserverextest"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var envVars = []string{
+	"KEPCTL_CONFIG",
+	"KEPCTL_PROFILE",
+	"KEPCTL_URL",
+	"KEPCTL_USERNAME",
+	"KEPCTL_PASSWORD",
+	"KEPCTL_INSECURE",
+}
+
+// setEnv clears all kepctl environment variables and sets the given ones.
+// It returns a function restoring the previous environment.
+func setEnv(t *testing.T, env map[string]string) func() {
+	old := make(map[string]*string)
+	for _, k := range envVars {
+		if v, ok := os.LookupEnv(k); ok {
+			old[k] = &v
+		} else {
+			old[k] = nil
+		}
+		os.Unsetenv(k)
+	}
+	for k, v := range env {
+		if err := os.Setenv(k, v); err != nil {
+			t.Fatalf("Failed to set %s: %v", k, err)
+		}
+	}
+
+	return func() {
+		for k, v := range old {
+			if v == nil {
+				os.Unsetenv(k)
+			} else {
+				os.Setenv(k, *v)
+			}
+		}
+	}
+}
+
+// writeConfig writes a config file with two profiles to a temporary
+// directory, and returns its path and a function removing it again.
+func writeConfig(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "kepctl")
+	if err != nil {
+		t.Fatalf("Failed to create temporary directory: %v", err)
+	}
+
+	path := filepath.Join(dir, "config.yaml")
+	data := []byte(`
+default: a
+profiles:
+  a:
+    url: https://a:57512
+    username: user-a
+    password: secret-a
+  b:
+    url: b:57512
+    username: user-b
+    password: secret-b
+    insecure: true
+`)
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestLoadConfig(t *testing.T) {
+	path, remove := writeConfig(t)
+	defer remove()
+
+	tests := []struct {
+		name    string
+		flags   flags
+		env     map[string]string
+		want    profile
+		wantErr bool
+	}{
+		{
+			name:  "default profile",
+			flags: flags{config: path},
+			want:  profile{URL: "https://a:57512", Username: "user-a", Password: "secret-a"},
+		},
+		{
+			name: "config from environment",
+			env:  map[string]string{"KEPCTL_CONFIG": path},
+			want: profile{URL: "https://a:57512", Username: "user-a", Password: "secret-a"},
+		},
+		{
+			name:  "profile from environment",
+			flags: flags{config: path},
+			env:   map[string]string{"KEPCTL_PROFILE": "b"},
+			want:  profile{URL: "b:57512", Username: "user-b", Password: "secret-b", Insecure: true},
+		},
+		{
+			name:  "profile flag before environment",
+			flags: flags{config: path, profile: "a"},
+			env:   map[string]string{"KEPCTL_PROFILE": "b"},
+			want:  profile{URL: "https://a:57512", Username: "user-a", Password: "secret-a"},
+		},
+		{
+			name:  "environment before profile",
+			flags: flags{config: path},
+			env:   map[string]string{"KEPCTL_URL": "env:57412", "KEPCTL_USERNAME": "user-env", "KEPCTL_PASSWORD": "secret-env"},
+			want:  profile{URL: "env:57412", Username: "user-env", Password: "secret-env"},
+		},
+		{
+			name:  "flags before environment",
+			flags: flags{config: path, url: "flag:57412", username: "user-flag", password: "secret-flag"},
+			env:   map[string]string{"KEPCTL_URL": "env:57412", "KEPCTL_USERNAME": "user-env", "KEPCTL_PASSWORD": "secret-env"},
+			want:  profile{URL: "flag:57412", Username: "user-flag", Password: "secret-flag"},
+		},
+		{
+			name:  "insecure from environment",
+			flags: flags{config: path},
+			env:   map[string]string{"KEPCTL_INSECURE": "1"},
+			want:  profile{URL: "https://a:57512", Username: "user-a", Password: "secret-a", Insecure: true},
+		},
+		{
+			name:  "environment disables insecure profile",
+			flags: flags{config: path, profile: "b"},
+			env:   map[string]string{"KEPCTL_INSECURE": "false"},
+			want:  profile{URL: "b:57512", Username: "user-b", Password: "secret-b"},
+		},
+		{
+			name:  "insecure flag before environment",
+			flags: flags{config: path, insecure: true},
+			env:   map[string]string{"KEPCTL_INSECURE": "false"},
+			want:  profile{URL: "https://a:57512", Username: "user-a", Password: "secret-a", Insecure: true},
+		},
+		{
+			name: "missing config from environment",
+			env: map[string]string{
+				"KEPCTL_CONFIG": filepath.Join(filepath.Dir(path), "missing.yaml"),
+				"KEPCTL_URL":    "env:57412",
+			},
+			want: profile{URL: "env:57412"},
+		},
+		{
+			name:    "invalid insecure value",
+			flags:   flags{config: path},
+			env:     map[string]string{"KEPCTL_INSECURE": "maybe"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown profile",
+			flags:   flags{config: path, profile: "c"},
+			wantErr: true,
+		},
+		{
+			name:    "missing config flag",
+			flags:   flags{config: filepath.Join(filepath.Dir(path), "missing.yaml"), url: "flag:57412"},
+			wantErr: true,
+		},
+		{
+			name:    "missing URL",
+			env:     map[string]string{"KEPCTL_CONFIG": filepath.Join(filepath.Dir(path), "missing.yaml")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer setEnv(t, tt.env)()
+
+			got, err := loadConfig(&tt.flags)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("loadConfig returned %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadConfig returned error: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("loadConfig returned %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{url: "kepware", want: "https://kepware/config/v1/project/"},
+		{url: "kepware:57512", want: "https://kepware:57512/config/v1/project/"},
+		{url: "http://kepware:57412", want: "http://kepware:57412/config/v1/project/"},
+		{url: "https://kepware:57512/config/v1/", want: "https://kepware:57512/config/v1/project/"},
+		{url: "ftp://kepware", wantErr: true},
+		{url: "https://kepware/other", wantErr: true},
+		{url: "https://", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		p := &profile{URL: tt.url, Username: "user", Password: "secret"}
+		c, err := p.newClient()
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("newClient for %q returned no error", tt.url)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("newClient for %q returned error: %v", tt.url, err)
+			continue
+		}
+		if got := c.BaseURL().String(); got != tt.want {
+			t.Errorf("newClient for %q has base URL %s, want %s", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestParseInterspersed(t *testing.T) {
+	tests := []struct {
+		args       []string
+		positional []string
+		output     string
+		wantErr    bool
+	}{
+		{args: []string{"tags", "list", "C.D"}, positional: []string{"tags", "list", "C.D"}, output: "table"},
+		{args: []string{"-o", "json", "tags", "list", "C.D"}, positional: []string{"tags", "list", "C.D"}, output: "json"},
+		{args: []string{"tags", "-o", "json", "list", "C.D"}, positional: []string{"tags", "list", "C.D"}, output: "json"},
+		{args: []string{"tags", "list", "C.D", "-o=yaml"}, positional: []string{"tags", "list", "C.D"}, output: "yaml"},
+		{args: []string{"tags", "delete", "--", "-Tag1"}, positional: []string{"tags", "delete", "-Tag1"}, output: "table"},
+		{args: []string{"--", "tags", "-o"}, positional: []string{"tags", "-o"}, output: "table"},
+		{args: []string{"tags", "list", "-unknown"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("kepctl", flag.ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+		output := fs.String("o", "table", "")
+
+		positional, err := parseInterspersed(fs, tt.args)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseInterspersed(%q) returned %q, want an error", tt.args, positional)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseInterspersed(%q) returned error: %v", tt.args, err)
+			continue
+		}
+		if !reflect.DeepEqual(positional, tt.positional) || *output != tt.output {
+			t.Errorf("parseInterspersed(%q) returned %q and -o %s, want %q and -o %s",
+				tt.args, positional, *output, tt.positional, tt.output)
+		}
+	}
+}
+
+// newTestServer starts a fake server with a Simulator channel and device,
+// and returns it together with the arguments to connect to it.
+func newTestServer(t *testing.T) (*kepserverextest.Server, []string) {
+	s := kepserverextest.NewServer("user", "secret")
+
+	c, err := s.NewClient()
+	if err != nil {
+		s.Close()
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	err = c.Channels.CreateChannel(&kepserverex.ChannelOptions{
+		Name:        "Channel1",
+		Description: "First channel",
+		Driver:      kepserverex.SimulatorDriver,
+	})
+	if err != nil {
+		s.Close()
+		t.Fatalf("CreateChannel returned error: %v", err)
+	}
+	if err := c.Devices.CreateSimulatorDevice("Channel1", &kepserverex.SimulatorDeviceOptions{Name: "Device1"}); err != nil {
+		s.Close()
+		t.Fatalf("CreateSimulatorDevice returned error: %v", err)
+	}
+
+	return s, []string{"-url", s.URL, "-username", s.Username, "-password", s.Password}
+}
+
+// runCommand runs kepctl with the given arguments and stdin, and returns
+// the output.
+func runCommand(stdin string, args ...string) (string, error) {
+	var stdout bytes.Buffer
+	err := run(args, strings.NewReader(stdin), &stdout)
+	return stdout.String(), err
+}
+
+func TestRun(t *testing.T) {
+	defer setEnv(t, map[string]string{"KEPCTL_CONFIG": filepath.Join(os.TempDir(), "kepctl-missing.yaml")})()
+
+	s, conn := newTestServer(t)
+	defer s.Close()
+
+	// Create a tag from YAML on stdin, with the flags after the arguments.
+	yamlTag := "common.ALLTYPES_NAME: Tag1\nservermain.TAG_ADDRESS: K0001\nservermain.TAG_DATA_TYPE: 3\n"
+	if _, err := runCommand(yamlTag, append([]string{"tags", "create", "Channel1.Device1", "-f", "-"}, conn...)...); err != nil {
+		t.Fatalf("tags create returned error: %v", err)
+	}
+
+	// Table output contains a header and a row per object.
+	out, err := runCommand("", append(conn, "channels", "list")...)
+	if err != nil {
+		t.Fatalf("channels list returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 ||
+		!reflect.DeepEqual(strings.Fields(lines[0]), []string{"NAME", "DRIVER", "DESCRIPTION"}) ||
+		!reflect.DeepEqual(strings.Fields(lines[1]), []string{"Channel1", kepserverex.SimulatorDriver, "First", "channel"}) {
+		t.Errorf("channels list returned:\n%s", out)
+	}
+
+	out, err = runCommand("", append(conn, "tags", "get", "Channel1.Device1.Tag1")...)
+	if err != nil {
+		t.Fatalf("tags get returned error: %v", err)
+	}
+	if !strings.Contains(out, "servermain.TAG_ADDRESS") || !strings.Contains(out, "K0001") {
+		t.Errorf("tags get returned:\n%s", out)
+	}
+
+	// JSON output contains the complete objects.
+	out, err = runCommand("", append(conn, "-o", "json", "tags", "list", "Channel1.Device1")...)
+	if err != nil {
+		t.Fatalf("tags list returned error: %v", err)
+	}
+	var jsonTags []map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &jsonTags); err != nil {
+		t.Fatalf("tags list returned invalid JSON %q: %v", out, err)
+	}
+	if len(jsonTags) != 1 || jsonTags[0]["common.ALLTYPES_NAME"] != "Tag1" ||
+		jsonTags[0]["servermain.TAG_ADDRESS"] != "K0001" || jsonTags[0]["servermain.TAG_DATA_TYPE"] != float64(3) {
+		t.Errorf("tags list returned %v, want Tag1", jsonTags)
+	}
+
+	// YAML output uses the property names and native numbers.
+	out, err = runCommand("", append(conn, "channels", "get", "Channel1", "-o", "yaml")...)
+	if err != nil {
+		t.Fatalf("channels get returned error: %v", err)
+	}
+	var yamlChannel map[string]interface{}
+	if err := yaml.Unmarshal([]byte(out), &yamlChannel); err != nil {
+		t.Fatalf("channels get returned invalid YAML %q: %v", out, err)
+	}
+	if yamlChannel["common.ALLTYPES_NAME"] != "Channel1" || yamlChannel["PROJECT_ID"] != int(s.ProjectID()) {
+		t.Errorf("channels get returned %v, want Channel1 with project ID %d", yamlChannel, s.ProjectID())
+	}
+
+	if _, err := runCommand("", append(conn, "tags", "delete", "Channel1.Device1.Tag1")...); err != nil {
+		t.Fatalf("tags delete returned error: %v", err)
+	}
+	out, err = runCommand("", append(conn, "-o", "json", "tags", "list", "Channel1.Device1")...)
+	if err != nil {
+		t.Fatalf("tags list returned error: %v", err)
+	}
+	if strings.TrimSpace(out) != "[]" {
+		t.Errorf("tags list after delete returned %q, want []", out)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	defer setEnv(t, map[string]string{"KEPCTL_CONFIG": filepath.Join(os.TempDir(), "kepctl-missing.yaml")})()
+
+	s, conn := newTestServer(t)
+	defer s.Close()
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"unknown resource", []string{"widgets", "list"}, "unknown resource"},
+		{"unknown action", []string{"channels", "rename"}, "unknown action"},
+		{"unknown output format", []string{"-o", "xml", "channels", "list"}, "unknown output format"},
+		{"missing file", []string{"channels", "create"}, "missing object properties"},
+		{"invalid device path", []string{"devices", "get", "Channel1"}, "invalid device"},
+		{"not found", []string{"channels", "get", "Missing"}, "404"},
+		{"unauthorized", []string{"-password", "wrong", "channels", "list"}, "401"},
+	}
+
+	for _, tt := range tests {
+		_, err := runCommand("", append(conn, tt.args...)...)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s returned error %v, want an error containing %q", tt.name, err, tt.want)
+		}
+	}
+
+}
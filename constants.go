@@ -16,6 +16,8 @@
 
 package kepserverex
 
+import "encoding/json"
+
 // ArrayBlockSize represents an array block size.
 type ArrayBlockSize int
 
@@ -31,6 +33,48 @@ const (
 	ArrayBlockSize_3840 ArrayBlockSize = 3840
 )
 
+// BACnetCOVMode represents a BACnet/IP COV (change of value) mode.
+type BACnetCOVMode int
+
+// List of available BACnet/IP COV modes.
+const (
+	BACnetCOVMode_Unconfirmed BACnetCOVMode = iota
+	BACnetCOVMode_Confirmed
+	BACnetCOVMode_Disabled
+)
+
+// BACnetIPModel represents a BACnet/IP model.
+type BACnetIPModel int
+
+// List of available BACnet/IP models.
+const (
+	BACnet BACnetIPModel = iota
+)
+
+// BACnetMaxAPDU represents a BACnet/IP maximum APDU length.
+type BACnetMaxAPDU int
+
+// List of available BACnet/IP maximum APDU lengths.
+const (
+	BACnetMaxAPDU_50   BACnetMaxAPDU = 50
+	BACnetMaxAPDU_128  BACnetMaxAPDU = 128
+	BACnetMaxAPDU_206  BACnetMaxAPDU = 206
+	BACnetMaxAPDU_480  BACnetMaxAPDU = 480
+	BACnetMaxAPDU_1024 BACnetMaxAPDU = 1024
+	BACnetMaxAPDU_1476 BACnetMaxAPDU = 1476
+)
+
+// BACnetSegmentation represents a BACnet/IP segmentation support type.
+type BACnetSegmentation int
+
+// List of available BACnet/IP segmentation support types.
+const (
+	BACnetSegmentation_Both BACnetSegmentation = iota
+	BACnetSegmentation_Transmit
+	BACnetSegmentation_Receive
+	BACnetSegmentation_None
+)
+
 // BautRate represents a baut rate.
 type BautRate int
 
@@ -53,6 +97,15 @@ const (
 	BautRate_256000 BautRate = 256000
 )
 
+// BeckhoffTwinCATModel represents a Beckhoff TwinCAT model.
+type BeckhoffTwinCATModel int
+
+// List of available Beckhoff TwinCAT models.
+const (
+	TwinCAT_2 BeckhoffTwinCATModel = iota
+	TwinCAT_3
+)
+
 // ByteOrder represents a byte order.
 type ByteOrder int
 
@@ -120,6 +173,17 @@ const (
 	ClientAccess_ReadWrite
 )
 
+// CoilBlockSize represents the number of coils read in a single request.
+// It must be a multiple of 8 between CoilBlockSize_Min and CoilBlockSize_Max.
+type CoilBlockSize int
+
+// List of available coil block size limits.
+const (
+	CoilBlockSize_Min     CoilBlockSize = 8
+	CoilBlockSize_Default CoilBlockSize = 32
+	CoilBlockSize_Max     CoilBlockSize = 2000
+)
+
 // ConnectionPriority represents a connection priority.
 type ConnectionPriority int
 
@@ -163,6 +227,15 @@ const (
 	MicroLogix_1400
 )
 
+// ControlLogixUnsolicitedModel represents a ControlLogix Unsolicited model.
+type ControlLogixUnsolicitedModel int
+
+// List of available ControlLogix Unsolicited models.
+const (
+	Unsolicited_ControlLogix ControlLogixUnsolicitedModel = iota
+	Unsolicited_CompactLogix
+)
+
 // DeadbandType represents a deadband type.
 type DeadbandType int
 
@@ -173,6 +246,89 @@ const (
 	DeadbandType_Absolute
 )
 
+// List of KEPServerEX driver names with a dedicated device type.
+const (
+	BACnetIPDriver                = "BACnet/IP"
+	BeckhoffTwinCATDriver         = "Beckhoff TwinCAT"
+	ControlLogixEthernetDriver    = "Allen-Bradley ControlLogix Ethernet"
+	ControlLogixUnsolicitedDriver = "Allen-Bradley ControlLogix Unsolicited"
+	DNP3ClientDriver              = "DNP3 Client"
+	Micro800EthernetDriver        = "Allen-Bradley Micro800 Ethernet"
+	MitsubishiEthernetDriver      = "Mitsubishi Ethernet"
+	ModbusTCPDriver               = "Modbus TCP/IP Ethernet"
+	OmronFINSEthernetDriver       = "Omron FINS Ethernet"
+	OPCUAClientDriver             = "OPC UA Client"
+	SiemensS5AS511Driver          = "Siemens S5 (AS511)"
+	SiemensTCPIPEthernetDriver    = "Siemens TCP/IP Ethernet"
+	SimulatorDriver               = "Simulator"
+	SNMPDriver                    = "SNMP"
+)
+
+// DNP3ClientModel represents a DNP3 Client model.
+type DNP3ClientModel int
+
+// List of available DNP3 Client models.
+const (
+	DNP3 DNP3ClientModel = iota
+)
+
+// DNP3Group represents a DNP3 object group.
+type DNP3Group int
+
+// List of available DNP3 object groups.
+const (
+	DNP3Group_BinaryInput        DNP3Group = 1
+	DNP3Group_DoubleBitInput     DNP3Group = 3
+	DNP3Group_BinaryOutput       DNP3Group = 10
+	DNP3Group_BinaryCommand      DNP3Group = 12
+	DNP3Group_Counter            DNP3Group = 20
+	DNP3Group_FrozenCounter      DNP3Group = 21
+	DNP3Group_AnalogInput        DNP3Group = 30
+	DNP3Group_AnalogOutputStatus DNP3Group = 40
+	DNP3Group_AnalogOutput       DNP3Group = 41
+	DNP3Group_TimeAndDate        DNP3Group = 50
+	DNP3Group_ClassData          DNP3Group = 60
+	DNP3Group_OctetString        DNP3Group = 110
+)
+
+// DNP3HMACAlgorithm represents a DNP3 secure authentication HMAC algorithm.
+type DNP3HMACAlgorithm int
+
+// List of available DNP3 HMAC algorithms.
+const (
+	DNP3HMACAlgorithm_SHA1 DNP3HMACAlgorithm = iota
+	DNP3HMACAlgorithm_SHA256
+)
+
+// DNP3KeyWrapAlgorithm represents a DNP3 secure authentication key wrap algorithm.
+type DNP3KeyWrapAlgorithm int
+
+// List of available DNP3 key wrap algorithms.
+const (
+	DNP3KeyWrapAlgorithm_AES128 DNP3KeyWrapAlgorithm = iota
+	DNP3KeyWrapAlgorithm_AES256
+)
+
+// DNP3TimeSync represents a DNP3 time synchronization style.
+type DNP3TimeSync int
+
+// List of available DNP3 time synchronization styles.
+const (
+	DNP3TimeSync_None DNP3TimeSync = iota
+	DNP3TimeSync_Serial
+	DNP3TimeSync_LAN
+)
+
+// DNP3UnsolicitedMode represents a DNP3 unsolicited messaging mode.
+type DNP3UnsolicitedMode int
+
+// List of available DNP3 unsolicited messaging modes.
+const (
+	DNP3UnsolicitedMode_Automatic DNP3UnsolicitedMode = iota
+	DNP3UnsolicitedMode_Enable
+	DNP3UnsolicitedMode_Disable
+)
+
 // FloatingPointValues represents a floating point option.
 type FloatingPointValues int
 
@@ -256,6 +412,53 @@ const (
 	MessageMode_SignAndEncrypt
 )
 
+// Micro800EthernetModel represents a Micro800 Ethernet model.
+type Micro800EthernetModel int
+
+// List of available Micro800 Ethernet models.
+const (
+	Micro800 Micro800EthernetModel = iota
+)
+
+// MitsubishiCPU represents a Mitsubishi CPU in a multiple CPU system.
+type MitsubishiCPU int
+
+// List of available Mitsubishi CPUs.
+const (
+	MitsubishiCPU_Local MitsubishiCPU = iota
+	MitsubishiCPU_1
+	MitsubishiCPU_2
+	MitsubishiCPU_3
+	MitsubishiCPU_4
+)
+
+// MitsubishiEthernetModel represents a Mitsubishi Ethernet model.
+type MitsubishiEthernetModel int
+
+// List of available Mitsubishi Ethernet models.
+const (
+	A_Series MitsubishiEthernetModel = iota
+	FX3U
+	Q_Series
+	L_Series
+	IQ_R
+	IQ_F
+)
+
+// ModbusTCPModel represents a Modbus TCP/IP Ethernet model.
+type ModbusTCPModel int
+
+// List of available Modbus TCP/IP Ethernet models.
+const (
+	Modbus ModbusTCPModel = iota
+	Applicom
+	EthernetToMBPlus
+	Fluenta
+	Instromet
+	Mailbox
+	Roxar
+)
+
 // NetworkMode represents a network mode.
 type NetworkMode int
 
@@ -265,6 +468,18 @@ const (
 	NetworkMode_Priority
 )
 
+// OmronFINSEthernetModel represents an Omron FINS Ethernet model.
+type OmronFINSEthernetModel int
+
+// List of available Omron FINS Ethernet models.
+const (
+	FINS_CS1 OmronFINSEthernetModel = iota
+	FINS_CJ1
+	FINS_CJ2
+	FINS_CV
+	FINS_NJ
+)
+
 // OnDeviceStartup represents a on device startup mode.
 type OnDeviceStartup int
 
@@ -353,6 +568,17 @@ const (
 	ReadProcessing_Fail
 )
 
+// RegisterBlockSize represents the number of registers read in a single
+// request. It must be between RegisterBlockSize_Min and RegisterBlockSize_Max.
+type RegisterBlockSize int
+
+// List of available register block size limits.
+const (
+	RegisterBlockSize_Min     RegisterBlockSize = 1
+	RegisterBlockSize_Default RegisterBlockSize = 32
+	RegisterBlockSize_Max     RegisterBlockSize = 120
+)
+
 // RequestSize represents a request size.
 type RequestSize int
 
@@ -447,6 +673,74 @@ const (
 	Netlink_S7_400
 )
 
+// SimulatorModel represents a Simulator model.
+type SimulatorModel int
+
+// List of available Simulator models.
+const (
+	Simulator_16Bit SimulatorModel = iota
+	Simulator_8Bit
+)
+
+// SimulatorRegister represents a Simulator register type.
+type SimulatorRegister string
+
+// List of available Simulator register types.
+const (
+	SimulatorRegister_Boolean  SimulatorRegister = "B"
+	SimulatorRegister_Constant SimulatorRegister = "K"
+	SimulatorRegister_Register SimulatorRegister = "R"
+	SimulatorRegister_String   SimulatorRegister = "S"
+)
+
+// SNMPAuthProtocol represents an SNMPv3 authentication protocol.
+type SNMPAuthProtocol int
+
+// List of available SNMPv3 authentication protocols.
+const (
+	SNMPAuthProtocol_MD5 SNMPAuthProtocol = iota
+	SNMPAuthProtocol_SHA
+)
+
+// SNMPModel represents an SNMP model.
+type SNMPModel int
+
+// List of available SNMP models.
+const (
+	SNMPAgent SNMPModel = iota
+)
+
+// SNMPPrivacyProtocol represents an SNMPv3 privacy protocol.
+type SNMPPrivacyProtocol int
+
+// List of available SNMPv3 privacy protocols.
+const (
+	SNMPPrivacyProtocol_DES SNMPPrivacyProtocol = iota
+	SNMPPrivacyProtocol_AES128
+	SNMPPrivacyProtocol_AES192
+	SNMPPrivacyProtocol_AES256
+)
+
+// SNMPSecurityLevel represents an SNMPv3 security level.
+type SNMPSecurityLevel int
+
+// List of available SNMPv3 security levels.
+const (
+	SNMPSecurityLevel_NoAuthNoPriv SNMPSecurityLevel = iota
+	SNMPSecurityLevel_AuthNoPriv
+	SNMPSecurityLevel_AuthPriv
+)
+
+// SNMPVersion represents an SNMP version.
+type SNMPVersion int
+
+// List of available SNMP versions.
+const (
+	SNMPVersion_V1 SNMPVersion = iota
+	SNMPVersion_V2c
+	SNMPVersion_V3
+)
+
 // StopBits represents a stop bit size.
 type StopBits int
 
@@ -981,3 +1275,33 @@ const (
 	VirtualNetwork_499
 	VirtualNetwork_500
 )
+
+// WordOrder represents the order of the words (or double words) in 32-bit
+// and 64-bit data types. KEPServerEX stores it as a boolean property telling
+// whether the low word comes first.
+type WordOrder int
+
+// List of available word orders.
+const (
+	WordOrder_HighFirst WordOrder = iota
+	WordOrder_LowFirst
+)
+
+// MarshalJSON implements the json.Marshaler interface.
+func (o WordOrder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o == WordOrder_LowFirst)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (o *WordOrder) UnmarshalJSON(data []byte) error {
+	var lowFirst bool
+	if err := json.Unmarshal(data, &lowFirst); err != nil {
+		return err
+	}
+	if lowFirst {
+		*o = WordOrder_LowFirst
+	} else {
+		*o = WordOrder_HighFirst
+	}
+	return nil
+}
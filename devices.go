@@ -17,6 +17,7 @@
 package kepserverex
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 )
@@ -37,14 +38,277 @@ type Device struct {
 	Driver            string `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER"`
 }
 
+// DeviceInfo implements the DeviceConfig interface. It is promoted to all
+// device types embedding a Device.
+func (d *Device) DeviceInfo() *Device {
+	info := *d
+	return &info
+}
+
+// DeviceConfig is implemented by all device types, so devices of different
+// drivers can be handled in a uniform way.
+type DeviceConfig interface {
+	// DeviceInfo returns the properties shared by all devices.
+	DeviceInfo() *Device
+}
+
+// deviceTypes maps driver names to a constructor of their device type.
+var deviceTypes = map[string]func() DeviceConfig{
+	BACnetIPDriver:                func() DeviceConfig { return new(BACnetIPDevice) },
+	BeckhoffTwinCATDriver:         func() DeviceConfig { return new(BeckhoffTwinCATDevice) },
+	ControlLogixEthernetDriver:    func() DeviceConfig { return new(ControlLogixEthernetDevice) },
+	ControlLogixUnsolicitedDriver: func() DeviceConfig { return new(ControlLogixUnsolicitedDevice) },
+	DNP3ClientDriver:              func() DeviceConfig { return new(DNP3ClientDevice) },
+	Micro800EthernetDriver:        func() DeviceConfig { return new(Micro800EthernetDevice) },
+	MitsubishiEthernetDriver:      func() DeviceConfig { return new(MitsubishiEthernetDevice) },
+	ModbusTCPDriver:               func() DeviceConfig { return new(ModbusTCPDevice) },
+	OmronFINSEthernetDriver:       func() DeviceConfig { return new(OmronFINSEthernetDevice) },
+	OPCUAClientDriver:             func() DeviceConfig { return new(OPCUAClientDevice) },
+	SiemensS5AS511Driver:          func() DeviceConfig { return new(SiemensS5AS511Device) },
+	SiemensTCPIPEthernetDriver:    func() DeviceConfig { return new(SiemensTCPIPEthernetDevice) },
+	SimulatorDriver:               func() DeviceConfig { return new(SimulatorDevice) },
+	SNMPDriver:                    func() DeviceConfig { return new(SNMPDevice) },
+}
+
+// GenericDevice represents a device of any driver by its raw properties,
+// keyed by their KEPServerEX property names (e.g. servermain.DEVICE_MODEL).
+// It can be used for drivers that do not have a dedicated device type.
+type GenericDevice Properties
+
+// Name returns the name of the device.
+func (d GenericDevice) Name() string {
+	return Properties(d).GetString("common.ALLTYPES_NAME")
+}
+
+// Description returns the description of the device.
+func (d GenericDevice) Description() string {
+	return Properties(d).GetString("common.ALLTYPES_DESCRIPTION")
+}
+
+// UniqueID returns the unique ID of the device.
+func (d GenericDevice) UniqueID() int64 {
+	return Properties(d).GetInt64("servermain.DEVICE_UNIQUE_ID")
+}
+
+// ProjectID returns the project ID the device was read with.
+func (d GenericDevice) ProjectID() int64 {
+	return Properties(d).GetInt64("PROJECT_ID")
+}
+
+// ChannelAssignment returns the name of the channel of the device.
+func (d GenericDevice) ChannelAssignment() string {
+	return Properties(d).GetString("servermain.DEVICE_CHANNEL_ASSIGNMENT")
+}
+
+// Driver returns the driver of the device.
+func (d GenericDevice) Driver() string {
+	return Properties(d).GetString("servermain.MULTIPLE_TYPES_DEVICE_DRIVER")
+}
+
+// Model returns the driver specific model of the device.
+func (d GenericDevice) Model() int {
+	return Properties(d).GetInt("servermain.DEVICE_MODEL")
+}
+
+// IDString returns the ID of the device as a string.
+func (d GenericDevice) IDString() string {
+	return Properties(d).GetString("servermain.DEVICE_ID_STRING")
+}
+
+// DataCollection returns whether data collection is enabled.
+func (d GenericDevice) DataCollection() bool {
+	return Properties(d).GetBool("servermain.DEVICE_DATA_COLLECTION")
+}
+
+// Simulated returns whether the device is simulated.
+func (d GenericDevice) Simulated() bool {
+	return Properties(d).GetBool("servermain.DEVICE_SIMULATED")
+}
+
+// ScanMode returns the scan mode of the device.
+func (d GenericDevice) ScanMode() ScanMode {
+	return ScanMode(Properties(d).GetInt("servermain.DEVICE_SCAN_MODE"))
+}
+
+// ScanRate returns the scan rate of the device in milliseconds.
+func (d GenericDevice) ScanRate() int {
+	return Properties(d).GetInt("servermain.DEVICE_SCAN_MODE_RATE_MS")
+}
+
+// DeviceInfo implements the DeviceConfig interface.
+func (d GenericDevice) DeviceInfo() *Device {
+	return &Device{
+		Name:              d.Name(),
+		Description:       d.Description(),
+		UniqueID:          d.UniqueID(),
+		ProjectID:         d.ProjectID(),
+		ChannelAssignment: d.ChannelAssignment(),
+		Driver:            d.Driver(),
+	}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *GenericDevice) UnmarshalJSON(data []byte) error {
+	return (*Properties)(d).UnmarshalJSON(data)
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (d GenericDevice) MarshalYAML() (interface{}, error) {
+	return Properties(d).MarshalYAML()
+}
+
+// BACnetIPDevice represents a BACnet/IP device.
+type BACnetIPDevice struct {
+	Device
+
+	Model                                BACnetIPModel      `json:"servermain.DEVICE_MODEL"`
+	IDFormat                             IDFormat           `json:"servermain.DEVICE_ID_FORMAT"`
+	IDString                             string             `json:"servermain.DEVICE_ID_STRING"`
+	DataCollection                       bool               `json:"servermain.DEVICE_DATA_COLLECTION"`
+	Simulated                            bool               `json:"servermain.DEVICE_SIMULATED"`
+	ScanMode                             ScanMode           `json:"servermain.DEVICE_SCAN_MODE"`
+	ScanRate                             int                `json:"servermain.DEVICE_SCAN_MODE_RATE_MS"`
+	InitialUpdatesFromCache              bool               `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE"`
+	ConnectionTimeout                    int                `json:"servermain.DEVICE_CONNECTION_TIMEOUT_SECONDS"`
+	RequestTimeout                       int                `json:"servermain.DEVICE_REQUEST_TIMEOUT_MILLISECONDS"`
+	AttemptsBeforeTimeout                int                `json:"servermain.DEVICE_RETRY_ATTEMPTS"`
+	InterRequestDelay                    int                `json:"servermain.DEVICE_INTER_REQUEST_DELAY_MILLISECONDS"`
+	DemoteOnFailure                      bool               `json:"servermain.DEVICE_AUTO_DEMOTION_ENABLE_ON_COMMUNICATIONS_FAILURES"`
+	TimeoutToDemote                      int                `json:"servermain.DEVICE_AUTO_DEMOTION_DEMOTE_AFTER_SUCCESSIVE_TIMEOUTS"`
+	DemotionPeriod                       int                `json:"servermain.DEVICE_AUTO_DEMOTION_PERIOD_MS"`
+	DiscardRequestsWhenDemoted           bool               `json:"servermain.DEVICE_AUTO_DEMOTION_DISCARD_WRITES"`
+	OnDeviceStartup                      OnDeviceStartup    `json:"servermain.DEVICE_TAG_GENERATION_ON_STARTUP"`
+	OnDuplicateTag                       OnDuplicateTag     `json:"servermain.DEVICE_TAG_GENERATION_DUPLICATE_HANDLING"`
+	ParentGroup                          string             `json:"servermain.DEVICE_TAG_GENERATION_GROUP"`
+	AllowAutomaticallyGeneratedSubgroups bool               `json:"servermain.DEVICE_TAG_GENERATION_ALLOW_SUB_GROUPS"`
+	NetworkNumber                        int                `json:"bacnet.DEVICE_NETWORK_NUMBER"`
+	MACAddress                           string             `json:"bacnet.DEVICE_MAC_ADDRESS"`
+	MaxAPDULength                        BACnetMaxAPDU      `json:"bacnet.DEVICE_MAX_APDU_LENGTH"`
+	Segmentation                         BACnetSegmentation `json:"bacnet.DEVICE_SEGMENTATION_SUPPORTED"`
+	MaxSegments                          int                `json:"bacnet.DEVICE_MAX_SEGMENTS"`
+	ReadPropertyMultiple                 bool               `json:"bacnet.DEVICE_READ_PROPERTY_MULTIPLE"`
+	WritePropertyMultiple                bool               `json:"bacnet.DEVICE_WRITE_PROPERTY_MULTIPLE"`
+	MaxItemsPerRequest                   int                `json:"bacnet.DEVICE_MAX_ITEMS_PER_REQUEST"`
+	CommandPriority                      int                `json:"bacnet.DEVICE_COMMAND_PRIORITY"`
+	COVMode                              BACnetCOVMode      `json:"bacnet.DEVICE_COV_MODE"`
+	COVResubscriptionInterval            int                `json:"bacnet.DEVICE_COV_RESUBSCRIPTION_INTERVAL_SECONDS"`
+	COVCancelOnShutdown                  bool               `json:"bacnet.DEVICE_COV_CANCEL_SUBSCRIPTIONS_ON_SHUTDOWN"`
+	COVNotificationsBeforeTimeout        int                `json:"bacnet.DEVICE_COV_MAX_MISSED_NOTIFICATIONS"`
+}
+
+// BACnetIPDeviceOptions represents all BACnet/IP device options.
+type BACnetIPDeviceOptions struct {
+	Name                                 string              `json:"common.ALLTYPES_NAME,omitempty"`
+	Description                          string              `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID                            int64               `json:"PROJECT_ID,omitempty"`
+	Driver                               string              `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER,omitempty"`
+	Model                                *BACnetIPModel      `json:"servermain.DEVICE_MODEL,omitempty"`
+	IDFormat                             IDFormat            `json:"servermain.DEVICE_ID_FORMAT,omitempty"`
+	IDString                             string              `json:"servermain.DEVICE_ID_STRING,omitempty"`
+	DataCollection                       *bool               `json:"servermain.DEVICE_DATA_COLLECTION,omitempty"`
+	Simulated                            *bool               `json:"servermain.DEVICE_SIMULATED,omitempty"`
+	ScanMode                             *ScanMode           `json:"servermain.DEVICE_SCAN_MODE,omitempty"`
+	ScanRate                             *int                `json:"servermain.DEVICE_SCAN_MODE_RATE_MS,omitempty"`
+	InitialUpdatesFromCache              *bool               `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE,omitempty"`
+	ConnectionTimeout                    *int                `json:"servermain.DEVICE_CONNECTION_TIMEOUT_SECONDS,omitempty"`
+	RequestTimeout                       *int                `json:"servermain.DEVICE_REQUEST_TIMEOUT_MILLISECONDS,omitempty"`
+	AttemptsBeforeTimeout                *int                `json:"servermain.DEVICE_RETRY_ATTEMPTS,omitempty"`
+	InterRequestDelay                    *int                `json:"servermain.DEVICE_INTER_REQUEST_DELAY_MILLISECONDS,omitempty"`
+	DemoteOnFailure                      *bool               `json:"servermain.DEVICE_AUTO_DEMOTION_ENABLE_ON_COMMUNICATIONS_FAILURES,omitempty"`
+	TimeoutToDemote                      *int                `json:"servermain.DEVICE_AUTO_DEMOTION_DEMOTE_AFTER_SUCCESSIVE_TIMEOUTS,omitempty"`
+	DemotionPeriod                       *int                `json:"servermain.DEVICE_AUTO_DEMOTION_PERIOD_MS,omitempty"`
+	DiscardRequestsWhenDemoted           *bool               `json:"servermain.DEVICE_AUTO_DEMOTION_DISCARD_WRITES,omitempty"`
+	OnDeviceStartup                      *OnDeviceStartup    `json:"servermain.DEVICE_TAG_GENERATION_ON_STARTUP,omitempty"`
+	OnDuplicateTag                       *OnDuplicateTag     `json:"servermain.DEVICE_TAG_GENERATION_DUPLICATE_HANDLING,omitempty"`
+	ParentGroup                          *string             `json:"servermain.DEVICE_TAG_GENERATION_GROUP,omitempty"`
+	AllowAutomaticallyGeneratedSubgroups *bool               `json:"servermain.DEVICE_TAG_GENERATION_ALLOW_SUB_GROUPS,omitempty"`
+	NetworkNumber                        *int                `json:"bacnet.DEVICE_NETWORK_NUMBER,omitempty"`
+	MACAddress                           *string             `json:"bacnet.DEVICE_MAC_ADDRESS,omitempty"`
+	MaxAPDULength                        *BACnetMaxAPDU      `json:"bacnet.DEVICE_MAX_APDU_LENGTH,omitempty"`
+	Segmentation                         *BACnetSegmentation `json:"bacnet.DEVICE_SEGMENTATION_SUPPORTED,omitempty"`
+	MaxSegments                          *int                `json:"bacnet.DEVICE_MAX_SEGMENTS,omitempty"`
+	ReadPropertyMultiple                 *bool               `json:"bacnet.DEVICE_READ_PROPERTY_MULTIPLE,omitempty"`
+	WritePropertyMultiple                *bool               `json:"bacnet.DEVICE_WRITE_PROPERTY_MULTIPLE,omitempty"`
+	MaxItemsPerRequest                   *int                `json:"bacnet.DEVICE_MAX_ITEMS_PER_REQUEST,omitempty"`
+	CommandPriority                      *int                `json:"bacnet.DEVICE_COMMAND_PRIORITY,omitempty"`
+	COVMode                              *BACnetCOVMode      `json:"bacnet.DEVICE_COV_MODE,omitempty"`
+	COVResubscriptionInterval            *int                `json:"bacnet.DEVICE_COV_RESUBSCRIPTION_INTERVAL_SECONDS,omitempty"`
+	COVCancelOnShutdown                  *bool               `json:"bacnet.DEVICE_COV_CANCEL_SUBSCRIPTIONS_ON_SHUTDOWN,omitempty"`
+	COVNotificationsBeforeTimeout        *int                `json:"bacnet.DEVICE_COV_MAX_MISSED_NOTIFICATIONS,omitempty"`
+}
+
+// BeckhoffTwinCATDevice represents a Beckhoff TwinCAT device.
+type BeckhoffTwinCATDevice struct {
+	Device
+
+	Model                                BeckhoffTwinCATModel `json:"servermain.DEVICE_MODEL"`
+	IDFormat                             IDFormat             `json:"servermain.DEVICE_ID_FORMAT"`
+	IDString                             string               `json:"servermain.DEVICE_ID_STRING"`
+	DataCollection                       bool                 `json:"servermain.DEVICE_DATA_COLLECTION"`
+	Simulated                            bool                 `json:"servermain.DEVICE_SIMULATED"`
+	ScanMode                             ScanMode             `json:"servermain.DEVICE_SCAN_MODE"`
+	ScanRate                             int                  `json:"servermain.DEVICE_SCAN_MODE_RATE_MS"`
+	InitialUpdatesFromCache              bool                 `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE"`
+	ConnectionTimeout                    int                  `json:"servermain.DEVICE_CONNECTION_TIMEOUT_SECONDS"`
+	RequestTimeout                       int                  `json:"servermain.DEVICE_REQUEST_TIMEOUT_MILLISECONDS"`
+	AttemptsBeforeTimeout                int                  `json:"servermain.DEVICE_RETRY_ATTEMPTS"`
+	InterRequestDelay                    int                  `json:"servermain.DEVICE_INTER_REQUEST_DELAY_MILLISECONDS"`
+	DemoteOnFailure                      bool                 `json:"servermain.DEVICE_AUTO_DEMOTION_ENABLE_ON_COMMUNICATIONS_FAILURES"`
+	TimeoutToDemote                      int                  `json:"servermain.DEVICE_AUTO_DEMOTION_DEMOTE_AFTER_SUCCESSIVE_TIMEOUTS"`
+	DemotionPeriod                       int                  `json:"servermain.DEVICE_AUTO_DEMOTION_PERIOD_MS"`
+	DiscardRequestsWhenDemoted           bool                 `json:"servermain.DEVICE_AUTO_DEMOTION_DISCARD_WRITES"`
+	OnDeviceStartup                      OnDeviceStartup      `json:"servermain.DEVICE_TAG_GENERATION_ON_STARTUP"`
+	OnDuplicateTag                       OnDuplicateTag       `json:"servermain.DEVICE_TAG_GENERATION_DUPLICATE_HANDLING"`
+	ParentGroup                          string               `json:"servermain.DEVICE_TAG_GENERATION_GROUP"`
+	AllowAutomaticallyGeneratedSubgroups bool                 `json:"servermain.DEVICE_TAG_GENERATION_ALLOW_SUB_GROUPS"`
+	RuntimePort                          int                  `json:"beckhoff_twincat.DEVICE_RUNTIME_PORT"`
+	SourceAMSNetID                       string               `json:"beckhoff_twincat.DEVICE_SOURCE_AMS_NET_ID"`
+	MaxItemsPerRequest                   int                  `json:"beckhoff_twincat.DEVICE_MAX_ITEMS_PER_REQUEST"`
+	SynchronizeOnStartup                 bool                 `json:"beckhoff_twincat.DEVICE_SYNCHRONIZE_SYMBOLS_ON_STARTUP"`
+	TagHierarchy                         TagHierarchy         `json:"beckhoff_twincat.DEVICE_TAG_HIERARCHY"`
+	ImposeArrayLimit                     bool                 `json:"beckhoff_twincat.DEVICE_IMPOSE_ARRAY_ELEMENT_COUNT_LIMIT"`
+	ArrayCountUpperLimit                 int                  `json:"beckhoff_twincat.DEVICE_ARRAY_ELEMENT_COUNT_LIMIT"`
+}
+
+// BeckhoffTwinCATDeviceOptions represents all Beckhoff TwinCAT device options.
+type BeckhoffTwinCATDeviceOptions struct {
+	Name                                 string                `json:"common.ALLTYPES_NAME,omitempty"`
+	Description                          string                `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID                            int64                 `json:"PROJECT_ID,omitempty"`
+	Driver                               string                `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER,omitempty"`
+	Model                                *BeckhoffTwinCATModel `json:"servermain.DEVICE_MODEL,omitempty"`
+	IDFormat                             IDFormat              `json:"servermain.DEVICE_ID_FORMAT,omitempty"`
+	IDString                             string                `json:"servermain.DEVICE_ID_STRING,omitempty"`
+	DataCollection                       *bool                 `json:"servermain.DEVICE_DATA_COLLECTION,omitempty"`
+	Simulated                            *bool                 `json:"servermain.DEVICE_SIMULATED,omitempty"`
+	ScanMode                             *ScanMode             `json:"servermain.DEVICE_SCAN_MODE,omitempty"`
+	ScanRate                             *int                  `json:"servermain.DEVICE_SCAN_MODE_RATE_MS,omitempty"`
+	InitialUpdatesFromCache              *bool                 `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE,omitempty"`
+	ConnectionTimeout                    *int                  `json:"servermain.DEVICE_CONNECTION_TIMEOUT_SECONDS,omitempty"`
+	RequestTimeout                       *int                  `json:"servermain.DEVICE_REQUEST_TIMEOUT_MILLISECONDS,omitempty"`
+	AttemptsBeforeTimeout                *int                  `json:"servermain.DEVICE_RETRY_ATTEMPTS,omitempty"`
+	InterRequestDelay                    *int                  `json:"servermain.DEVICE_INTER_REQUEST_DELAY_MILLISECONDS,omitempty"`
+	DemoteOnFailure                      *bool                 `json:"servermain.DEVICE_AUTO_DEMOTION_ENABLE_ON_COMMUNICATIONS_FAILURES,omitempty"`
+	TimeoutToDemote                      *int                  `json:"servermain.DEVICE_AUTO_DEMOTION_DEMOTE_AFTER_SUCCESSIVE_TIMEOUTS,omitempty"`
+	DemotionPeriod                       *int                  `json:"servermain.DEVICE_AUTO_DEMOTION_PERIOD_MS,omitempty"`
+	DiscardRequestsWhenDemoted           *bool                 `json:"servermain.DEVICE_AUTO_DEMOTION_DISCARD_WRITES,omitempty"`
+	OnDeviceStartup                      *OnDeviceStartup      `json:"servermain.DEVICE_TAG_GENERATION_ON_STARTUP,omitempty"`
+	OnDuplicateTag                       *OnDuplicateTag       `json:"servermain.DEVICE_TAG_GENERATION_DUPLICATE_HANDLING,omitempty"`
+	ParentGroup                          *string               `json:"servermain.DEVICE_TAG_GENERATION_GROUP,omitempty"`
+	AllowAutomaticallyGeneratedSubgroups *bool                 `json:"servermain.DEVICE_TAG_GENERATION_ALLOW_SUB_GROUPS,omitempty"`
+	RuntimePort                          *int                  `json:"beckhoff_twincat.DEVICE_RUNTIME_PORT,omitempty"`
+	SourceAMSNetID                       *string               `json:"beckhoff_twincat.DEVICE_SOURCE_AMS_NET_ID,omitempty"`
+	MaxItemsPerRequest                   *int                  `json:"beckhoff_twincat.DEVICE_MAX_ITEMS_PER_REQUEST,omitempty"`
+	SynchronizeOnStartup                 *bool                 `json:"beckhoff_twincat.DEVICE_SYNCHRONIZE_SYMBOLS_ON_STARTUP,omitempty"`
+	TagHierarchy                         *TagHierarchy         `json:"beckhoff_twincat.DEVICE_TAG_HIERARCHY,omitempty"`
+	ImposeArrayLimit                     *bool                 `json:"beckhoff_twincat.DEVICE_IMPOSE_ARRAY_ELEMENT_COUNT_LIMIT,omitempty"`
+	ArrayCountUpperLimit                 *int                  `json:"beckhoff_twincat.DEVICE_ARRAY_ELEMENT_COUNT_LIMIT,omitempty"`
+}
+
 // ControlLogixEthernetDevice represents an ControlLogix Ethernet device.
 type ControlLogixEthernetDevice struct {
-	Name                                 string                    `json:"common.ALLTYPES_NAME"`
-	Description                          string                    `json:"common.ALLTYPES_DESCRIPTION"`
-	UniqueID                             int64                     `json:"servermain.DEVICE_UNIQUE_ID"`
-	ProjectID                            int64                     `json:"PROJECT_ID"`
-	ChannelAssignment                    string                    `json:"servermain.DEVICE_CHANNEL_ASSIGNMENT"`
-	Driver                               string                    `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER"`
+	Device
+
 	Model                                ControlLogixEthernetModel `json:"servermain.DEVICE_MODEL"`
 	IDFormat                             IDFormat                  `json:"servermain.DEVICE_ID_FORMAT"`
 	IDString                             string                    `json:"servermain.DEVICE_ID_STRING"`
@@ -99,6 +363,7 @@ type ControlLogixEthernetDevice struct {
 type ControlLogixEthernetDeviceOptions struct {
 	Name                                 string                    `json:"common.ALLTYPES_NAME,omitempty"`
 	Description                          string                    `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID                            int64                     `json:"PROJECT_ID,omitempty"`
 	Driver                               string                    `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER"`
 	Model                                ControlLogixEthernetModel `json:"servermain.DEVICE_MODEL"`
 	IDFormat                             IDFormat                  `json:"servermain.DEVICE_ID_FORMAT,omitempty"`
@@ -145,14 +410,418 @@ type ControlLogixEthernetDeviceOptions struct {
 	AllowFunctionFileBlockWrites         *bool                     `json:"controllogix_ethernet.DEVICE_PERFORM_BLOCK_WRITES,omitempty"`
 }
 
+// ControlLogixUnsolicitedDevice represents a ControlLogix Unsolicited device.
+type ControlLogixUnsolicitedDevice struct {
+	Device
+
+	Model                    ControlLogixUnsolicitedModel `json:"servermain.DEVICE_MODEL"`
+	IDFormat                 IDFormat                     `json:"servermain.DEVICE_ID_FORMAT"`
+	IDString                 string                       `json:"servermain.DEVICE_ID_STRING"`
+	DataCollection           bool                         `json:"servermain.DEVICE_DATA_COLLECTION"`
+	Simulated                bool                         `json:"servermain.DEVICE_SIMULATED"`
+	ScanMode                 ScanMode                     `json:"servermain.DEVICE_SCAN_MODE"`
+	ScanRate                 int                          `json:"servermain.DEVICE_SCAN_MODE_RATE_MS"`
+	InitialUpdatesFromCache  bool                         `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE"`
+	UnsolicitedTimeout       int                          `json:"controllogix_unsolicited.DEVICE_UNSOLICITED_TIMEOUT_SECONDS"`
+	ValidateSourceAddress    bool                         `json:"controllogix_unsolicited.DEVICE_VALIDATE_SOURCE_IP"`
+	DefaultDataType          DataType                     `json:"controllogix_unsolicited.DEVICE_DEFAULT_DATA_TYPE"`
+	TerminateStringDataAtLEN bool                         `json:"controllogix_unsolicited.DEVICE_AUTOMATICALLY_READ_STRING_LENGTH"`
+	PerformanceStatistics    bool                         `json:"controllogix_unsolicited.DEVICE_ENABLE_PERFORMANCE_STATISTICS"`
+}
+
+// ControlLogixUnsolicitedDeviceOptions represents all ControlLogix Unsolicited device options.
+type ControlLogixUnsolicitedDeviceOptions struct {
+	Name                     string                       `json:"common.ALLTYPES_NAME,omitempty"`
+	Description              string                       `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID                int64                        `json:"PROJECT_ID,omitempty"`
+	Driver                   string                       `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER"`
+	Model                    ControlLogixUnsolicitedModel `json:"servermain.DEVICE_MODEL"`
+	IDFormat                 IDFormat                     `json:"servermain.DEVICE_ID_FORMAT,omitempty"`
+	IDString                 string                       `json:"servermain.DEVICE_ID_STRING,omitempty"`
+	DataCollection           *bool                        `json:"servermain.DEVICE_DATA_COLLECTION,omitempty"`
+	Simulated                *bool                        `json:"servermain.DEVICE_SIMULATED,omitempty"`
+	ScanMode                 *ScanMode                    `json:"servermain.DEVICE_SCAN_MODE,omitempty"`
+	ScanRate                 *int                         `json:"servermain.DEVICE_SCAN_MODE_RATE_MS,omitempty"`
+	InitialUpdatesFromCache  *bool                        `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE,omitempty"`
+	UnsolicitedTimeout       *int                         `json:"controllogix_unsolicited.DEVICE_UNSOLICITED_TIMEOUT_SECONDS,omitempty"`
+	ValidateSourceAddress    *bool                        `json:"controllogix_unsolicited.DEVICE_VALIDATE_SOURCE_IP,omitempty"`
+	DefaultDataType          *DataType                    `json:"controllogix_unsolicited.DEVICE_DEFAULT_DATA_TYPE,omitempty"`
+	TerminateStringDataAtLEN *bool                        `json:"controllogix_unsolicited.DEVICE_AUTOMATICALLY_READ_STRING_LENGTH,omitempty"`
+	PerformanceStatistics    *bool                        `json:"controllogix_unsolicited.DEVICE_ENABLE_PERFORMANCE_STATISTICS,omitempty"`
+}
+
+// DNP3ClientDevice represents a DNP3 Client device.
+type DNP3ClientDevice struct {
+	Device
+
+	Model                       DNP3ClientModel      `json:"servermain.DEVICE_MODEL"`
+	IDFormat                    IDFormat             `json:"servermain.DEVICE_ID_FORMAT"`
+	IDString                    string               `json:"servermain.DEVICE_ID_STRING"`
+	DataCollection              bool                 `json:"servermain.DEVICE_DATA_COLLECTION"`
+	Simulated                   bool                 `json:"servermain.DEVICE_SIMULATED"`
+	ScanMode                    ScanMode             `json:"servermain.DEVICE_SCAN_MODE"`
+	ScanRate                    int                  `json:"servermain.DEVICE_SCAN_MODE_RATE_MS"`
+	InitialUpdatesFromCache     bool                 `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE"`
+	MasterAddress               int                  `json:"dnp3_client.DEVICE_MASTER_ADDRESS"`
+	OutstationAddress           int                  `json:"dnp3_client.DEVICE_OUTSTATION_ADDRESS"`
+	RequestTimeout              int                  `json:"dnp3_client.DEVICE_REQUEST_TIMEOUT_MS"`
+	MaxTimeouts                 int                  `json:"dnp3_client.DEVICE_MAX_TIMEOUTS"`
+	KeepAliveInterval           int                  `json:"dnp3_client.DEVICE_KEEP_ALIVE_INTERVAL_SECONDS"`
+	TimeSync                    DNP3TimeSync         `json:"dnp3_client.DEVICE_TIME_SYNC_STYLE"`
+	IntegrityPollInterval       int                  `json:"dnp3_client.DEVICE_INTEGRITY_POLL_INTERVAL_SECONDS"`
+	IntegrityPollOnRestart      bool                 `json:"dnp3_client.DEVICE_INTEGRITY_POLL_ON_RESTART"`
+	IntegrityPollOnReconnect    bool                 `json:"dnp3_client.DEVICE_INTEGRITY_POLL_ON_RECONNECT"`
+	Class1PollInterval          int                  `json:"dnp3_client.DEVICE_EVENT_CLASS_1_POLL_INTERVAL_MS"`
+	Class2PollInterval          int                  `json:"dnp3_client.DEVICE_EVENT_CLASS_2_POLL_INTERVAL_MS"`
+	Class3PollInterval          int                  `json:"dnp3_client.DEVICE_EVENT_CLASS_3_POLL_INTERVAL_MS"`
+	Class1Unsolicited           DNP3UnsolicitedMode  `json:"dnp3_client.DEVICE_UNSOLICITED_MODE_CLASS_1"`
+	Class2Unsolicited           DNP3UnsolicitedMode  `json:"dnp3_client.DEVICE_UNSOLICITED_MODE_CLASS_2"`
+	Class3Unsolicited           DNP3UnsolicitedMode  `json:"dnp3_client.DEVICE_UNSOLICITED_MODE_CLASS_3"`
+	DisableUnsolicitedOnStartup bool                 `json:"dnp3_client.DEVICE_DISABLE_UNSOLICITED_DURING_STARTUP"`
+	Authentication              bool                 `json:"dnp3_client.DEVICE_AUTHENTICATION"`
+	AuthenticationUserNumber    int                  `json:"dnp3_client.DEVICE_AUTHENTICATION_USER_NUMBER"`
+	AuthenticationUpdateKey     string               `json:"dnp3_client.DEVICE_AUTHENTICATION_UPDATE_KEY"`
+	KeyWrapAlgorithm            DNP3KeyWrapAlgorithm `json:"dnp3_client.DEVICE_AUTHENTICATION_KEY_WRAP_ALGORITHM"`
+	HMACAlgorithm               DNP3HMACAlgorithm    `json:"dnp3_client.DEVICE_AUTHENTICATION_HMAC_ALGORITHM"`
+	AuthenticationReplyTimeout  int                  `json:"dnp3_client.DEVICE_AUTHENTICATION_REPLY_TIMEOUT_MS"`
+	KeyChangeInterval           int                  `json:"dnp3_client.DEVICE_AUTHENTICATION_KEY_CHANGE_INTERVAL_SECONDS"`
+	AggressiveMode              bool                 `json:"dnp3_client.DEVICE_AUTHENTICATION_AGGRESSIVE_MODE"`
+}
+
+// DNP3ClientDeviceOptions represents all DNP3 Client device options.
+type DNP3ClientDeviceOptions struct {
+	Name                        string                `json:"common.ALLTYPES_NAME,omitempty"`
+	Description                 string                `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID                   int64                 `json:"PROJECT_ID,omitempty"`
+	Driver                      string                `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER,omitempty"`
+	Model                       *DNP3ClientModel      `json:"servermain.DEVICE_MODEL,omitempty"`
+	IDFormat                    IDFormat              `json:"servermain.DEVICE_ID_FORMAT,omitempty"`
+	IDString                    string                `json:"servermain.DEVICE_ID_STRING,omitempty"`
+	DataCollection              *bool                 `json:"servermain.DEVICE_DATA_COLLECTION,omitempty"`
+	Simulated                   *bool                 `json:"servermain.DEVICE_SIMULATED,omitempty"`
+	ScanMode                    *ScanMode             `json:"servermain.DEVICE_SCAN_MODE,omitempty"`
+	ScanRate                    *int                  `json:"servermain.DEVICE_SCAN_MODE_RATE_MS,omitempty"`
+	InitialUpdatesFromCache     *bool                 `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE,omitempty"`
+	MasterAddress               *int                  `json:"dnp3_client.DEVICE_MASTER_ADDRESS,omitempty"`
+	OutstationAddress           *int                  `json:"dnp3_client.DEVICE_OUTSTATION_ADDRESS,omitempty"`
+	RequestTimeout              *int                  `json:"dnp3_client.DEVICE_REQUEST_TIMEOUT_MS,omitempty"`
+	MaxTimeouts                 *int                  `json:"dnp3_client.DEVICE_MAX_TIMEOUTS,omitempty"`
+	KeepAliveInterval           *int                  `json:"dnp3_client.DEVICE_KEEP_ALIVE_INTERVAL_SECONDS,omitempty"`
+	TimeSync                    *DNP3TimeSync         `json:"dnp3_client.DEVICE_TIME_SYNC_STYLE,omitempty"`
+	IntegrityPollInterval       *int                  `json:"dnp3_client.DEVICE_INTEGRITY_POLL_INTERVAL_SECONDS,omitempty"`
+	IntegrityPollOnRestart      *bool                 `json:"dnp3_client.DEVICE_INTEGRITY_POLL_ON_RESTART,omitempty"`
+	IntegrityPollOnReconnect    *bool                 `json:"dnp3_client.DEVICE_INTEGRITY_POLL_ON_RECONNECT,omitempty"`
+	Class1PollInterval          *int                  `json:"dnp3_client.DEVICE_EVENT_CLASS_1_POLL_INTERVAL_MS,omitempty"`
+	Class2PollInterval          *int                  `json:"dnp3_client.DEVICE_EVENT_CLASS_2_POLL_INTERVAL_MS,omitempty"`
+	Class3PollInterval          *int                  `json:"dnp3_client.DEVICE_EVENT_CLASS_3_POLL_INTERVAL_MS,omitempty"`
+	Class1Unsolicited           *DNP3UnsolicitedMode  `json:"dnp3_client.DEVICE_UNSOLICITED_MODE_CLASS_1,omitempty"`
+	Class2Unsolicited           *DNP3UnsolicitedMode  `json:"dnp3_client.DEVICE_UNSOLICITED_MODE_CLASS_2,omitempty"`
+	Class3Unsolicited           *DNP3UnsolicitedMode  `json:"dnp3_client.DEVICE_UNSOLICITED_MODE_CLASS_3,omitempty"`
+	DisableUnsolicitedOnStartup *bool                 `json:"dnp3_client.DEVICE_DISABLE_UNSOLICITED_DURING_STARTUP,omitempty"`
+	Authentication              *bool                 `json:"dnp3_client.DEVICE_AUTHENTICATION,omitempty"`
+	AuthenticationUserNumber    *int                  `json:"dnp3_client.DEVICE_AUTHENTICATION_USER_NUMBER,omitempty"`
+	AuthenticationUpdateKey     *string               `json:"dnp3_client.DEVICE_AUTHENTICATION_UPDATE_KEY,omitempty"`
+	KeyWrapAlgorithm            *DNP3KeyWrapAlgorithm `json:"dnp3_client.DEVICE_AUTHENTICATION_KEY_WRAP_ALGORITHM,omitempty"`
+	HMACAlgorithm               *DNP3HMACAlgorithm    `json:"dnp3_client.DEVICE_AUTHENTICATION_HMAC_ALGORITHM,omitempty"`
+	AuthenticationReplyTimeout  *int                  `json:"dnp3_client.DEVICE_AUTHENTICATION_REPLY_TIMEOUT_MS,omitempty"`
+	KeyChangeInterval           *int                  `json:"dnp3_client.DEVICE_AUTHENTICATION_KEY_CHANGE_INTERVAL_SECONDS,omitempty"`
+	AggressiveMode              *bool                 `json:"dnp3_client.DEVICE_AUTHENTICATION_AGGRESSIVE_MODE,omitempty"`
+}
+
+// Micro800EthernetDevice represents a Micro800 Ethernet device.
+type Micro800EthernetDevice struct {
+	Device
+
+	Model                                Micro800EthernetModel `json:"servermain.DEVICE_MODEL"`
+	IDFormat                             IDFormat              `json:"servermain.DEVICE_ID_FORMAT"`
+	IDString                             string                `json:"servermain.DEVICE_ID_STRING"`
+	DataCollection                       bool                  `json:"servermain.DEVICE_DATA_COLLECTION"`
+	Simulated                            bool                  `json:"servermain.DEVICE_SIMULATED"`
+	ScanMode                             ScanMode              `json:"servermain.DEVICE_SCAN_MODE"`
+	ScanRate                             int                   `json:"servermain.DEVICE_SCAN_MODE_RATE_MS"`
+	InitialUpdatesFromCache              bool                  `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE"`
+	ConnectionTimeout                    int                   `json:"servermain.DEVICE_CONNECTION_TIMEOUT_SECONDS"`
+	RequestTimeout                       int                   `json:"servermain.DEVICE_REQUEST_TIMEOUT_MILLISECONDS"`
+	AttemptsBeforeTimeout                int                   `json:"servermain.DEVICE_RETRY_ATTEMPTS"`
+	InterRequestDelay                    int                   `json:"servermain.DEVICE_INTER_REQUEST_DELAY_MILLISECONDS"`
+	DemoteOnFailure                      bool                  `json:"servermain.DEVICE_AUTO_DEMOTION_ENABLE_ON_COMMUNICATIONS_FAILURES"`
+	TimeoutToDemote                      int                   `json:"servermain.DEVICE_AUTO_DEMOTION_DEMOTE_AFTER_SUCCESSIVE_TIMEOUTS"`
+	DemotionPeriod                       int                   `json:"servermain.DEVICE_AUTO_DEMOTION_PERIOD_MS"`
+	DiscardRequestsWhenDemoted           bool                  `json:"servermain.DEVICE_AUTO_DEMOTION_DISCARD_WRITES"`
+	OnDeviceStartup                      OnDeviceStartup       `json:"servermain.DEVICE_TAG_GENERATION_ON_STARTUP"`
+	OnDuplicateTag                       OnDuplicateTag        `json:"servermain.DEVICE_TAG_GENERATION_DUPLICATE_HANDLING"`
+	ParentGroup                          string                `json:"servermain.DEVICE_TAG_GENERATION_GROUP"`
+	AllowAutomaticallyGeneratedSubgroups bool                  `json:"servermain.DEVICE_TAG_GENERATION_ALLOW_SUB_GROUPS"`
+	TCPIPPort                            int                   `json:"micro800_ethernet.DEVICE_PORT_NUMBER"`
+	ConnectionSize                       int                   `json:"micro800_ethernet.DEVICE_CONNECTION_SIZE_BYTES"`
+	InactivityWatchdog                   InactivityWatchdog    `json:"micro800_ethernet.DEVICE_INACTIVITY_WATCHDOG_SECONDS"`
+	ArrayBlockSize                       ArrayBlockSize        `json:"micro800_ethernet.DEVICE_ARRAY_BLOCK_SIZE_ELEMENTS"`
+	TerminateStringDataAtLEN             bool                  `json:"micro800_ethernet.DEVICE_AUTOMATICALLY_READ_STRING_LENGTH"`
+	DefaultDataType                      DataType              `json:"micro800_ethernet.DEVICE_DEFAULT_DATA_TYPE"`
+	PerformanceStatistics                bool                  `json:"micro800_ethernet.DEVICE_ENABLE_PERFORMANCE_STATISTICS"`
+	TagDescriptions                      bool                  `json:"micro800_ethernet.DEVICE_DISPLAY_DESCRIPTIONS"`
+	LimitNameLength                      bool                  `json:"micro800_ethernet.DEVICE_LIMIT_TAG_NAMES"`
+	ImposeArrayLimit                     bool                  `json:"micro800_ethernet.DEVICE_IMPOSE_ARRAY_ELEMENT_COUNT_LIMIT"`
+	ArrayCountUpperLimit                 int                   `json:"micro800_ethernet.DEVICE_ARRAY_ELEMENT_COUNT_LIMIT"`
+}
+
+// Micro800EthernetDeviceOptions represents all Micro800 Ethernet device options.
+type Micro800EthernetDeviceOptions struct {
+	Name                                 string                `json:"common.ALLTYPES_NAME,omitempty"`
+	Description                          string                `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID                            int64                 `json:"PROJECT_ID,omitempty"`
+	Driver                               string                `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER"`
+	Model                                Micro800EthernetModel `json:"servermain.DEVICE_MODEL"`
+	IDFormat                             IDFormat              `json:"servermain.DEVICE_ID_FORMAT,omitempty"`
+	IDString                             string                `json:"servermain.DEVICE_ID_STRING,omitempty"`
+	DataCollection                       *bool                 `json:"servermain.DEVICE_DATA_COLLECTION,omitempty"`
+	Simulated                            *bool                 `json:"servermain.DEVICE_SIMULATED,omitempty"`
+	ScanMode                             *ScanMode             `json:"servermain.DEVICE_SCAN_MODE,omitempty"`
+	ScanRate                             *int                  `json:"servermain.DEVICE_SCAN_MODE_RATE_MS,omitempty"`
+	InitialUpdatesFromCache              *bool                 `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE,omitempty"`
+	ConnectionTimeout                    *int                  `json:"servermain.DEVICE_CONNECTION_TIMEOUT_SECONDS,omitempty"`
+	RequestTimeout                       *int                  `json:"servermain.DEVICE_REQUEST_TIMEOUT_MILLISECONDS,omitempty"`
+	AttemptsBeforeTimeout                *int                  `json:"servermain.DEVICE_RETRY_ATTEMPTS,omitempty"`
+	InterRequestDelay                    *int                  `json:"servermain.DEVICE_INTER_REQUEST_DELAY_MILLISECONDS,omitempty"`
+	DemoteOnFailure                      *bool                 `json:"servermain.DEVICE_AUTO_DEMOTION_ENABLE_ON_COMMUNICATIONS_FAILURES,omitempty"`
+	TimeoutToDemote                      *int                  `json:"servermain.DEVICE_AUTO_DEMOTION_DEMOTE_AFTER_SUCCESSIVE_TIMEOUTS,omitempty"`
+	DemotionPeriod                       *int                  `json:"servermain.DEVICE_AUTO_DEMOTION_PERIOD_MS,omitempty"`
+	DiscardRequestsWhenDemoted           *bool                 `json:"servermain.DEVICE_AUTO_DEMOTION_DISCARD_WRITES,omitempty"`
+	OnDeviceStartup                      *OnDeviceStartup      `json:"servermain.DEVICE_TAG_GENERATION_ON_STARTUP,omitempty"`
+	OnDuplicateTag                       *OnDuplicateTag       `json:"servermain.DEVICE_TAG_GENERATION_DUPLICATE_HANDLING,omitempty"`
+	ParentGroup                          *string               `json:"servermain.DEVICE_TAG_GENERATION_GROUP,omitempty"`
+	AllowAutomaticallyGeneratedSubgroups *bool                 `json:"servermain.DEVICE_TAG_GENERATION_ALLOW_SUB_GROUPS,omitempty"`
+	TCPIPPort                            *int                  `json:"micro800_ethernet.DEVICE_PORT_NUMBER,omitempty"`
+	ConnectionSize                       *int                  `json:"micro800_ethernet.DEVICE_CONNECTION_SIZE_BYTES,omitempty"`
+	InactivityWatchdog                   *InactivityWatchdog   `json:"micro800_ethernet.DEVICE_INACTIVITY_WATCHDOG_SECONDS,omitempty"`
+	ArrayBlockSize                       *ArrayBlockSize       `json:"micro800_ethernet.DEVICE_ARRAY_BLOCK_SIZE_ELEMENTS,omitempty"`
+	TerminateStringDataAtLEN             *bool                 `json:"micro800_ethernet.DEVICE_AUTOMATICALLY_READ_STRING_LENGTH,omitempty"`
+	DefaultDataType                      *DataType             `json:"micro800_ethernet.DEVICE_DEFAULT_DATA_TYPE,omitempty"`
+	PerformanceStatistics                *bool                 `json:"micro800_ethernet.DEVICE_ENABLE_PERFORMANCE_STATISTICS,omitempty"`
+	TagDescriptions                      *bool                 `json:"micro800_ethernet.DEVICE_DISPLAY_DESCRIPTIONS,omitempty"`
+	LimitNameLength                      *bool                 `json:"micro800_ethernet.DEVICE_LIMIT_TAG_NAMES,omitempty"`
+	ImposeArrayLimit                     *bool                 `json:"micro800_ethernet.DEVICE_IMPOSE_ARRAY_ELEMENT_COUNT_LIMIT,omitempty"`
+	ArrayCountUpperLimit                 *int                  `json:"micro800_ethernet.DEVICE_ARRAY_ELEMENT_COUNT_LIMIT,omitempty"`
+}
+
+// MitsubishiEthernetDevice represents a Mitsubishi Ethernet device.
+type MitsubishiEthernetDevice struct {
+	Device
+
+	Model                      MitsubishiEthernetModel `json:"servermain.DEVICE_MODEL"`
+	IDFormat                   IDFormat                `json:"servermain.DEVICE_ID_FORMAT"`
+	IDString                   string                  `json:"servermain.DEVICE_ID_STRING"`
+	DataCollection             bool                    `json:"servermain.DEVICE_DATA_COLLECTION"`
+	Simulated                  bool                    `json:"servermain.DEVICE_SIMULATED"`
+	ScanMode                   ScanMode                `json:"servermain.DEVICE_SCAN_MODE"`
+	ScanRate                   int                     `json:"servermain.DEVICE_SCAN_MODE_RATE_MS"`
+	InitialUpdatesFromCache    bool                    `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE"`
+	ConnectionTimeout          int                     `json:"servermain.DEVICE_CONNECTION_TIMEOUT_SECONDS"`
+	RequestTimeout             int                     `json:"servermain.DEVICE_REQUEST_TIMEOUT_MILLISECONDS"`
+	AttemptsBeforeTimeout      int                     `json:"servermain.DEVICE_RETRY_ATTEMPTS"`
+	InterRequestDelay          int                     `json:"servermain.DEVICE_INTER_REQUEST_DELAY_MILLISECONDS"`
+	DemoteOnFailure            bool                    `json:"servermain.DEVICE_AUTO_DEMOTION_ENABLE_ON_COMMUNICATIONS_FAILURES"`
+	TimeoutToDemote            int                     `json:"servermain.DEVICE_AUTO_DEMOTION_DEMOTE_AFTER_SUCCESSIVE_TIMEOUTS"`
+	DemotionPeriod             int                     `json:"servermain.DEVICE_AUTO_DEMOTION_PERIOD_MS"`
+	DiscardRequestsWhenDemoted bool                    `json:"servermain.DEVICE_AUTO_DEMOTION_DISCARD_WRITES"`
+	PortNumber                 int                     `json:"mitsubishi_ethernet.DEVICE_PORT_NUMBER"`
+	IPProtocol                 Protocol                `json:"mitsubishi_ethernet.DEVICE_IP_PROTOCOL"`
+	NetworkNumber              int                     `json:"mitsubishi_ethernet.DEVICE_NETWORK_NUMBER"`
+	PCNumber                   int                     `json:"mitsubishi_ethernet.DEVICE_PC_NUMBER"`
+	CPU                        MitsubishiCPU           `json:"mitsubishi_ethernet.DEVICE_CPU"`
+	WordOrder                  WordOrder               `json:"mitsubishi_ethernet.DEVICE_FIRST_WORD_LOW"`
+	MaxWordsPerRequest         int                     `json:"mitsubishi_ethernet.DEVICE_MAX_WORDS_PER_REQUEST"`
+}
+
+// MitsubishiEthernetDeviceOptions represents all Mitsubishi Ethernet device options.
+type MitsubishiEthernetDeviceOptions struct {
+	Name                       string                   `json:"common.ALLTYPES_NAME,omitempty"`
+	Description                string                   `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID                  int64                    `json:"PROJECT_ID,omitempty"`
+	Driver                     string                   `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER,omitempty"`
+	Model                      *MitsubishiEthernetModel `json:"servermain.DEVICE_MODEL,omitempty"`
+	IDFormat                   IDFormat                 `json:"servermain.DEVICE_ID_FORMAT,omitempty"`
+	IDString                   string                   `json:"servermain.DEVICE_ID_STRING,omitempty"`
+	DataCollection             *bool                    `json:"servermain.DEVICE_DATA_COLLECTION,omitempty"`
+	Simulated                  *bool                    `json:"servermain.DEVICE_SIMULATED,omitempty"`
+	ScanMode                   *ScanMode                `json:"servermain.DEVICE_SCAN_MODE,omitempty"`
+	ScanRate                   *int                     `json:"servermain.DEVICE_SCAN_MODE_RATE_MS,omitempty"`
+	InitialUpdatesFromCache    *bool                    `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE,omitempty"`
+	ConnectionTimeout          *int                     `json:"servermain.DEVICE_CONNECTION_TIMEOUT_SECONDS,omitempty"`
+	RequestTimeout             *int                     `json:"servermain.DEVICE_REQUEST_TIMEOUT_MILLISECONDS,omitempty"`
+	AttemptsBeforeTimeout      *int                     `json:"servermain.DEVICE_RETRY_ATTEMPTS,omitempty"`
+	InterRequestDelay          *int                     `json:"servermain.DEVICE_INTER_REQUEST_DELAY_MILLISECONDS,omitempty"`
+	DemoteOnFailure            *bool                    `json:"servermain.DEVICE_AUTO_DEMOTION_ENABLE_ON_COMMUNICATIONS_FAILURES,omitempty"`
+	TimeoutToDemote            *int                     `json:"servermain.DEVICE_AUTO_DEMOTION_DEMOTE_AFTER_SUCCESSIVE_TIMEOUTS,omitempty"`
+	DemotionPeriod             *int                     `json:"servermain.DEVICE_AUTO_DEMOTION_PERIOD_MS,omitempty"`
+	DiscardRequestsWhenDemoted *bool                    `json:"servermain.DEVICE_AUTO_DEMOTION_DISCARD_WRITES,omitempty"`
+	PortNumber                 *int                     `json:"mitsubishi_ethernet.DEVICE_PORT_NUMBER,omitempty"`
+	IPProtocol                 *Protocol                `json:"mitsubishi_ethernet.DEVICE_IP_PROTOCOL,omitempty"`
+	NetworkNumber              *int                     `json:"mitsubishi_ethernet.DEVICE_NETWORK_NUMBER,omitempty"`
+	PCNumber                   *int                     `json:"mitsubishi_ethernet.DEVICE_PC_NUMBER,omitempty"`
+	CPU                        *MitsubishiCPU           `json:"mitsubishi_ethernet.DEVICE_CPU,omitempty"`
+	WordOrder                  *WordOrder               `json:"mitsubishi_ethernet.DEVICE_FIRST_WORD_LOW,omitempty"`
+	MaxWordsPerRequest         *int                     `json:"mitsubishi_ethernet.DEVICE_MAX_WORDS_PER_REQUEST,omitempty"`
+}
+
+// ModbusTCPDevice represents a Modbus TCP/IP Ethernet device.
+type ModbusTCPDevice struct {
+	Device
+
+	Model                          ModbusTCPModel    `json:"servermain.DEVICE_MODEL"`
+	IDFormat                       IDFormat          `json:"servermain.DEVICE_ID_FORMAT"`
+	IDString                       string            `json:"servermain.DEVICE_ID_STRING"`
+	DataCollection                 bool              `json:"servermain.DEVICE_DATA_COLLECTION"`
+	Simulated                      bool              `json:"servermain.DEVICE_SIMULATED"`
+	ScanMode                       ScanMode          `json:"servermain.DEVICE_SCAN_MODE"`
+	ScanRate                       int               `json:"servermain.DEVICE_SCAN_MODE_RATE_MS"`
+	InitialUpdatesFromCache        bool              `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE"`
+	ConnectionTimeout              int               `json:"servermain.DEVICE_CONNECTION_TIMEOUT_SECONDS"`
+	RequestTimeout                 int               `json:"servermain.DEVICE_REQUEST_TIMEOUT_MILLISECONDS"`
+	AttemptsBeforeTimeout          int               `json:"servermain.DEVICE_RETRY_ATTEMPTS"`
+	InterRequestDelay              int               `json:"servermain.DEVICE_INTER_REQUEST_DELAY_MILLISECONDS"`
+	DemoteOnFailure                bool              `json:"servermain.DEVICE_AUTO_DEMOTION_ENABLE_ON_COMMUNICATIONS_FAILURES"`
+	TimeoutToDemote                int               `json:"servermain.DEVICE_AUTO_DEMOTION_DEMOTE_AFTER_SUCCESSIVE_TIMEOUTS"`
+	DemotionPeriod                 int               `json:"servermain.DEVICE_AUTO_DEMOTION_PERIOD_MS"`
+	DiscardRequestsWhenDemoted     bool              `json:"servermain.DEVICE_AUTO_DEMOTION_DISCARD_WRITES"`
+	PortNumber                     int               `json:"modbus_tcpip_ethernet.DEVICE_ETHERNET_PORT_NUMBER"`
+	IPProtocol                     Protocol          `json:"modbus_tcpip_ethernet.DEVICE_ETHERNET_IP_PROTOCOL"`
+	CloseSocketOnTimeout           bool              `json:"modbus_tcpip_ethernet.DEVICE_CLOSE_TCP_SOCKET_ON_TIMEOUT"`
+	ZeroBasedAddressing            bool              `json:"modbus_tcpip_ethernet.DEVICE_ZERO_BASED_ADDRESSING"`
+	ZeroBasedBitAddressing         bool              `json:"modbus_tcpip_ethernet.DEVICE_ZERO_BASED_BIT_ADDRESSING"`
+	HoldingRegisterBitMaskWrites   bool              `json:"modbus_tcpip_ethernet.DEVICE_HOLDING_REGISTER_BIT_MASK_WRITES"`
+	UseFunction06                  bool              `json:"modbus_tcpip_ethernet.DEVICE_MODBUS_FUNCTION_06"`
+	UseFunction05                  bool              `json:"modbus_tcpip_ethernet.DEVICE_MODBUS_FUNCTION_05"`
+	CEGExtension                   bool              `json:"modbus_tcpip_ethernet.DEVICE_CEG_EXTENSION"`
+	ModbusByteOrder                bool              `json:"modbus_tcpip_ethernet.DEVICE_MODBUS_BYTE_ORDER"`
+	WordOrder                      WordOrder         `json:"modbus_tcpip_ethernet.DEVICE_FIRST_WORD_LOW"`
+	DWordOrder                     WordOrder         `json:"modbus_tcpip_ethernet.DEVICE_FIRST_DWORD_LOW"`
+	ModiconBitOrder                bool              `json:"modbus_tcpip_ethernet.DEVICE_MODICON_BIT_ORDER"`
+	TreatLongsAsDecimals           bool              `json:"modbus_tcpip_ethernet.DEVICE_TREAT_LONGS_AS_DECIMALS"`
+	OutputCoils                    CoilBlockSize     `json:"modbus_tcpip_ethernet.DEVICE_OUTPUT_COILS"`
+	InputCoils                     CoilBlockSize     `json:"modbus_tcpip_ethernet.DEVICE_INPUT_COILS"`
+	InternalRegisters              RegisterBlockSize `json:"modbus_tcpip_ethernet.DEVICE_INTERNAL_REGISTERS"`
+	HoldingRegisters               RegisterBlockSize `json:"modbus_tcpip_ethernet.DEVICE_HOLDING_REGISTERS"`
+	BlockReadStrings               bool              `json:"modbus_tcpip_ethernet.DEVICE_BLOCK_READ_STRINGS"`
+	DeactivateTagsOnIllegalAddress bool              `json:"modbus_tcpip_ethernet.DEVICE_DEACTIVATE_TAGS_ON_ILLEGAL_ADDRESS"`
+	RejectRepeatedMessages         bool              `json:"modbus_tcpip_ethernet.DEVICE_REJECT_REPEATED_MESSAGES"`
+}
+
+// ModbusTCPDeviceOptions represents all Modbus TCP/IP Ethernet device options.
+type ModbusTCPDeviceOptions struct {
+	Name                           string             `json:"common.ALLTYPES_NAME,omitempty"`
+	Description                    string             `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID                      int64              `json:"PROJECT_ID,omitempty"`
+	Driver                         string             `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER,omitempty"`
+	Model                          *ModbusTCPModel    `json:"servermain.DEVICE_MODEL,omitempty"`
+	IDFormat                       IDFormat           `json:"servermain.DEVICE_ID_FORMAT,omitempty"`
+	IDString                       string             `json:"servermain.DEVICE_ID_STRING,omitempty"`
+	DataCollection                 *bool              `json:"servermain.DEVICE_DATA_COLLECTION,omitempty"`
+	Simulated                      *bool              `json:"servermain.DEVICE_SIMULATED,omitempty"`
+	ScanMode                       *ScanMode          `json:"servermain.DEVICE_SCAN_MODE,omitempty"`
+	ScanRate                       *int               `json:"servermain.DEVICE_SCAN_MODE_RATE_MS,omitempty"`
+	InitialUpdatesFromCache        *bool              `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE,omitempty"`
+	ConnectionTimeout              *int               `json:"servermain.DEVICE_CONNECTION_TIMEOUT_SECONDS,omitempty"`
+	RequestTimeout                 *int               `json:"servermain.DEVICE_REQUEST_TIMEOUT_MILLISECONDS,omitempty"`
+	AttemptsBeforeTimeout          *int               `json:"servermain.DEVICE_RETRY_ATTEMPTS,omitempty"`
+	InterRequestDelay              *int               `json:"servermain.DEVICE_INTER_REQUEST_DELAY_MILLISECONDS,omitempty"`
+	DemoteOnFailure                *bool              `json:"servermain.DEVICE_AUTO_DEMOTION_ENABLE_ON_COMMUNICATIONS_FAILURES,omitempty"`
+	TimeoutToDemote                *int               `json:"servermain.DEVICE_AUTO_DEMOTION_DEMOTE_AFTER_SUCCESSIVE_TIMEOUTS,omitempty"`
+	DemotionPeriod                 *int               `json:"servermain.DEVICE_AUTO_DEMOTION_PERIOD_MS,omitempty"`
+	DiscardRequestsWhenDemoted     *bool              `json:"servermain.DEVICE_AUTO_DEMOTION_DISCARD_WRITES,omitempty"`
+	PortNumber                     *int               `json:"modbus_tcpip_ethernet.DEVICE_ETHERNET_PORT_NUMBER,omitempty"`
+	IPProtocol                     *Protocol          `json:"modbus_tcpip_ethernet.DEVICE_ETHERNET_IP_PROTOCOL,omitempty"`
+	CloseSocketOnTimeout           *bool              `json:"modbus_tcpip_ethernet.DEVICE_CLOSE_TCP_SOCKET_ON_TIMEOUT,omitempty"`
+	ZeroBasedAddressing            *bool              `json:"modbus_tcpip_ethernet.DEVICE_ZERO_BASED_ADDRESSING,omitempty"`
+	ZeroBasedBitAddressing         *bool              `json:"modbus_tcpip_ethernet.DEVICE_ZERO_BASED_BIT_ADDRESSING,omitempty"`
+	HoldingRegisterBitMaskWrites   *bool              `json:"modbus_tcpip_ethernet.DEVICE_HOLDING_REGISTER_BIT_MASK_WRITES,omitempty"`
+	UseFunction06                  *bool              `json:"modbus_tcpip_ethernet.DEVICE_MODBUS_FUNCTION_06,omitempty"`
+	UseFunction05                  *bool              `json:"modbus_tcpip_ethernet.DEVICE_MODBUS_FUNCTION_05,omitempty"`
+	CEGExtension                   *bool              `json:"modbus_tcpip_ethernet.DEVICE_CEG_EXTENSION,omitempty"`
+	ModbusByteOrder                *bool              `json:"modbus_tcpip_ethernet.DEVICE_MODBUS_BYTE_ORDER,omitempty"`
+	WordOrder                      *WordOrder         `json:"modbus_tcpip_ethernet.DEVICE_FIRST_WORD_LOW,omitempty"`
+	DWordOrder                     *WordOrder         `json:"modbus_tcpip_ethernet.DEVICE_FIRST_DWORD_LOW,omitempty"`
+	ModiconBitOrder                *bool              `json:"modbus_tcpip_ethernet.DEVICE_MODICON_BIT_ORDER,omitempty"`
+	TreatLongsAsDecimals           *bool              `json:"modbus_tcpip_ethernet.DEVICE_TREAT_LONGS_AS_DECIMALS,omitempty"`
+	OutputCoils                    *CoilBlockSize     `json:"modbus_tcpip_ethernet.DEVICE_OUTPUT_COILS,omitempty"`
+	InputCoils                     *CoilBlockSize     `json:"modbus_tcpip_ethernet.DEVICE_INPUT_COILS,omitempty"`
+	InternalRegisters              *RegisterBlockSize `json:"modbus_tcpip_ethernet.DEVICE_INTERNAL_REGISTERS,omitempty"`
+	HoldingRegisters               *RegisterBlockSize `json:"modbus_tcpip_ethernet.DEVICE_HOLDING_REGISTERS,omitempty"`
+	BlockReadStrings               *bool              `json:"modbus_tcpip_ethernet.DEVICE_BLOCK_READ_STRINGS,omitempty"`
+	DeactivateTagsOnIllegalAddress *bool              `json:"modbus_tcpip_ethernet.DEVICE_DEACTIVATE_TAGS_ON_ILLEGAL_ADDRESS,omitempty"`
+	RejectRepeatedMessages         *bool              `json:"modbus_tcpip_ethernet.DEVICE_REJECT_REPEATED_MESSAGES,omitempty"`
+}
+
+// OmronFINSEthernetDevice represents an Omron FINS Ethernet device.
+type OmronFINSEthernetDevice struct {
+	Device
+
+	Model                      OmronFINSEthernetModel `json:"servermain.DEVICE_MODEL"`
+	IDFormat                   IDFormat               `json:"servermain.DEVICE_ID_FORMAT"`
+	IDString                   string                 `json:"servermain.DEVICE_ID_STRING"`
+	DataCollection             bool                   `json:"servermain.DEVICE_DATA_COLLECTION"`
+	Simulated                  bool                   `json:"servermain.DEVICE_SIMULATED"`
+	ScanMode                   ScanMode               `json:"servermain.DEVICE_SCAN_MODE"`
+	ScanRate                   int                    `json:"servermain.DEVICE_SCAN_MODE_RATE_MS"`
+	InitialUpdatesFromCache    bool                   `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE"`
+	ConnectionTimeout          int                    `json:"servermain.DEVICE_CONNECTION_TIMEOUT_SECONDS"`
+	RequestTimeout             int                    `json:"servermain.DEVICE_REQUEST_TIMEOUT_MILLISECONDS"`
+	AttemptsBeforeTimeout      int                    `json:"servermain.DEVICE_RETRY_ATTEMPTS"`
+	InterRequestDelay          int                    `json:"servermain.DEVICE_INTER_REQUEST_DELAY_MILLISECONDS"`
+	DemoteOnFailure            bool                   `json:"servermain.DEVICE_AUTO_DEMOTION_ENABLE_ON_COMMUNICATIONS_FAILURES"`
+	TimeoutToDemote            int                    `json:"servermain.DEVICE_AUTO_DEMOTION_DEMOTE_AFTER_SUCCESSIVE_TIMEOUTS"`
+	DemotionPeriod             int                    `json:"servermain.DEVICE_AUTO_DEMOTION_PERIOD_MS"`
+	DiscardRequestsWhenDemoted bool                   `json:"servermain.DEVICE_AUTO_DEMOTION_DISCARD_WRITES"`
+	PortNumber                 int                    `json:"omron_fins_ethernet.DEVICE_PORT_NUMBER"`
+	IPProtocol                 Protocol               `json:"omron_fins_ethernet.DEVICE_IP_PROTOCOL"`
+	SourceNetwork              int                    `json:"omron_fins_ethernet.DEVICE_SOURCE_NETWORK_ADDRESS"`
+	SourceNode                 int                    `json:"omron_fins_ethernet.DEVICE_SOURCE_NODE_ADDRESS"`
+	SourceUnit                 int                    `json:"omron_fins_ethernet.DEVICE_SOURCE_UNIT_ADDRESS"`
+	DestinationNetwork         int                    `json:"omron_fins_ethernet.DEVICE_DESTINATION_NETWORK_ADDRESS"`
+	DestinationNode            int                    `json:"omron_fins_ethernet.DEVICE_DESTINATION_NODE_ADDRESS"`
+	DestinationUnit            int                    `json:"omron_fins_ethernet.DEVICE_DESTINATION_UNIT_ADDRESS"`
+	MaxRequestSize             int                    `json:"omron_fins_ethernet.DEVICE_MAX_REQUEST_SIZE_WORDS"`
+	SetRunModeOnWrite          bool                   `json:"omron_fins_ethernet.DEVICE_SET_RUN_MODE_ON_WRITE"`
+}
+
+// OmronFINSEthernetDeviceOptions represents all Omron FINS Ethernet device options.
+type OmronFINSEthernetDeviceOptions struct {
+	Name                       string                  `json:"common.ALLTYPES_NAME,omitempty"`
+	Description                string                  `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID                  int64                   `json:"PROJECT_ID,omitempty"`
+	Driver                     string                  `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER,omitempty"`
+	Model                      *OmronFINSEthernetModel `json:"servermain.DEVICE_MODEL,omitempty"`
+	IDFormat                   IDFormat                `json:"servermain.DEVICE_ID_FORMAT,omitempty"`
+	IDString                   string                  `json:"servermain.DEVICE_ID_STRING,omitempty"`
+	DataCollection             *bool                   `json:"servermain.DEVICE_DATA_COLLECTION,omitempty"`
+	Simulated                  *bool                   `json:"servermain.DEVICE_SIMULATED,omitempty"`
+	ScanMode                   *ScanMode               `json:"servermain.DEVICE_SCAN_MODE,omitempty"`
+	ScanRate                   *int                    `json:"servermain.DEVICE_SCAN_MODE_RATE_MS,omitempty"`
+	InitialUpdatesFromCache    *bool                   `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE,omitempty"`
+	ConnectionTimeout          *int                    `json:"servermain.DEVICE_CONNECTION_TIMEOUT_SECONDS,omitempty"`
+	RequestTimeout             *int                    `json:"servermain.DEVICE_REQUEST_TIMEOUT_MILLISECONDS,omitempty"`
+	AttemptsBeforeTimeout      *int                    `json:"servermain.DEVICE_RETRY_ATTEMPTS,omitempty"`
+	InterRequestDelay          *int                    `json:"servermain.DEVICE_INTER_REQUEST_DELAY_MILLISECONDS,omitempty"`
+	DemoteOnFailure            *bool                   `json:"servermain.DEVICE_AUTO_DEMOTION_ENABLE_ON_COMMUNICATIONS_FAILURES,omitempty"`
+	TimeoutToDemote            *int                    `json:"servermain.DEVICE_AUTO_DEMOTION_DEMOTE_AFTER_SUCCESSIVE_TIMEOUTS,omitempty"`
+	DemotionPeriod             *int                    `json:"servermain.DEVICE_AUTO_DEMOTION_PERIOD_MS,omitempty"`
+	DiscardRequestsWhenDemoted *bool                   `json:"servermain.DEVICE_AUTO_DEMOTION_DISCARD_WRITES,omitempty"`
+	PortNumber                 *int                    `json:"omron_fins_ethernet.DEVICE_PORT_NUMBER,omitempty"`
+	IPProtocol                 *Protocol               `json:"omron_fins_ethernet.DEVICE_IP_PROTOCOL,omitempty"`
+	SourceNetwork              *int                    `json:"omron_fins_ethernet.DEVICE_SOURCE_NETWORK_ADDRESS,omitempty"`
+	SourceNode                 *int                    `json:"omron_fins_ethernet.DEVICE_SOURCE_NODE_ADDRESS,omitempty"`
+	SourceUnit                 *int                    `json:"omron_fins_ethernet.DEVICE_SOURCE_UNIT_ADDRESS,omitempty"`
+	DestinationNetwork         *int                    `json:"omron_fins_ethernet.DEVICE_DESTINATION_NETWORK_ADDRESS,omitempty"`
+	DestinationNode            *int                    `json:"omron_fins_ethernet.DEVICE_DESTINATION_NODE_ADDRESS,omitempty"`
+	DestinationUnit            *int                    `json:"omron_fins_ethernet.DEVICE_DESTINATION_UNIT_ADDRESS,omitempty"`
+	MaxRequestSize             *int                    `json:"omron_fins_ethernet.DEVICE_MAX_REQUEST_SIZE_WORDS,omitempty"`
+	SetRunModeOnWrite          *bool                   `json:"omron_fins_ethernet.DEVICE_SET_RUN_MODE_ON_WRITE,omitempty"`
+}
+
 // OPCUAClientDevice represents an OPC UA client device.
 type OPCUAClientDevice struct {
-	Name                       string             `json:"common.ALLTYPES_NAME"`
-	Description                string             `json:"common.ALLTYPES_DESCRIPTION"`
-	UniqueID                   int64              `json:"servermain.DEVICE_UNIQUE_ID"`
-	ProjectID                  int64              `json:"PROJECT_ID"`
-	ChannelAssignment          string             `json:"servermain.DEVICE_CHANNEL_ASSIGNMENT"`
-	Driver                     string             `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER"`
+	Device
+
 	Model                      OPCUAClientModel   `json:"servermain.DEVICE_MODEL"`
 	DataCollection             bool               `json:"servermain.DEVICE_DATA_COLLECTION"`
 	Simulated                  bool               `json:"servermain.DEVICE_SIMULATED"`
@@ -183,6 +852,7 @@ type OPCUAClientDevice struct {
 type OPCUAClientDeviceOptions struct {
 	Name                       string              `json:"common.ALLTYPES_NAME,omitempty"`
 	Description                string              `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID                  int64               `json:"PROJECT_ID,omitempty"`
 	UniqueID                   int64               `json:"servermain.DEVICE_UNIQUE_ID,omitempty"`
 	Model                      OPCUAClientModel    `json:"servermain.DEVICE_MODEL,omitempty"`
 	DataCollection             *bool               `json:"servermain.DEVICE_DATA_COLLECTION,omitempty"`
@@ -212,12 +882,8 @@ type OPCUAClientDeviceOptions struct {
 
 // SiemensS5AS511Device represents a Siemens S5 (AS511) device.
 type SiemensS5AS511Device struct {
-	Name                       string              `json:"common.ALLTYPES_NAME"`
-	Description                string              `json:"common.ALLTYPES_DESCRIPTION"`
-	UniqueID                   int64               `json:"servermain.DEVICE_UNIQUE_ID"`
-	ProjectID                  int64               `json:"PROJECT_ID"`
-	ChannelAssignment          string              `json:"servermain.DEVICE_CHANNEL_ASSIGNMENT"`
-	Driver                     string              `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER"`
+	Device
+
 	Model                      SiemensS5AS511Model `json:"servermain.DEVICE_MODEL"`
 	IDFormat                   IDFormat            `json:"servermain.DEVICE_ID_FORMAT"`
 	IDString                   string              `json:"servermain.DEVICE_ID_STRING"`
@@ -245,6 +911,7 @@ type SiemensS5AS511Device struct {
 type SiemensS5AS511DeviceOptions struct {
 	Name                       string              `json:"common.ALLTYPES_NAME,omitempty"`
 	Description                string              `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID                  int64               `json:"PROJECT_ID,omitempty"`
 	UniqueID                   int64               `json:"servermain.DEVICE_UNIQUE_ID,omitempty"`
 	Driver                     string              `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER"`
 	Model                      SiemensS5AS511Model `json:"servermain.DEVICE_MODEL,omitempty"`
@@ -272,12 +939,8 @@ type SiemensS5AS511DeviceOptions struct {
 
 // SiemensTCPIPEthernetDevice represents a Siemens TCP/IP Ethernet device.
 type SiemensTCPIPEthernetDevice struct {
-	Name                                 string                    `json:"common.ALLTYPES_NAME"`
-	Description                          string                    `json:"common.ALLTYPES_DESCRIPTION"`
-	UniqueID                             int64                     `json:"servermain.DEVICE_UNIQUE_ID"`
-	ProjectID                            int64                     `json:"PROJECT_ID"`
-	ChannelAssignment                    string                    `json:"servermain.DEVICE_CHANNEL_ASSIGNMENT"`
-	Driver                               string                    `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER"`
+	Device
+
 	Model                                SiemensTCPIPEthernetModel `json:"servermain.DEVICE_MODEL"`
 	IDFormat                             IDFormat                  `json:"servermain.DEVICE_ID_FORMAT"`
 	IDString                             string                    `json:"servermain.DEVICE_ID_STRING"`
@@ -321,6 +984,7 @@ type SiemensTCPIPEthernetDevice struct {
 type SiemensTCPIPEthernetDeviceOptions struct {
 	Name                                 string                    `json:"common.ALLTYPES_NAME,omitempty"`
 	Description                          string                    `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID                            int64                     `json:"PROJECT_ID,omitempty"`
 	UniqueID                             int64                     `json:"servermain.DEVICE_UNIQUE_ID,omitempty"`
 	Driver                               string                    `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER"`
 	Model                                SiemensTCPIPEthernetModel `json:"servermain.DEVICE_MODEL,omitempty"`
@@ -362,11 +1026,111 @@ type SiemensTCPIPEthernetDeviceOptions struct {
 	TIAPortalExporterFile                *string                   `json:"siemens_tcpip_ethernet.DEVICE_TAG_IMPORT_TIA_EXPORT_FILE,omitempty"`
 }
 
+// SimulatorDevice represents a Simulator device.
+type SimulatorDevice struct {
+	Device
+
+	Model                   SimulatorModel `json:"servermain.DEVICE_MODEL"`
+	IDString                string         `json:"servermain.DEVICE_ID_STRING"`
+	DataCollection          bool           `json:"servermain.DEVICE_DATA_COLLECTION"`
+	Simulated               bool           `json:"servermain.DEVICE_SIMULATED"`
+	ScanMode                ScanMode       `json:"servermain.DEVICE_SCAN_MODE"`
+	ScanRate                int            `json:"servermain.DEVICE_SCAN_MODE_RATE_MS"`
+	InitialUpdatesFromCache bool           `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE"`
+}
+
+// SimulatorDeviceOptions represents all Simulator device options.
+type SimulatorDeviceOptions struct {
+	Name                    string          `json:"common.ALLTYPES_NAME,omitempty"`
+	Description             string          `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID               int64           `json:"PROJECT_ID,omitempty"`
+	Driver                  string          `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER,omitempty"`
+	Model                   *SimulatorModel `json:"servermain.DEVICE_MODEL,omitempty"`
+	IDString                string          `json:"servermain.DEVICE_ID_STRING,omitempty"`
+	DataCollection          *bool           `json:"servermain.DEVICE_DATA_COLLECTION,omitempty"`
+	Simulated               *bool           `json:"servermain.DEVICE_SIMULATED,omitempty"`
+	ScanMode                *ScanMode       `json:"servermain.DEVICE_SCAN_MODE,omitempty"`
+	ScanRate                *int            `json:"servermain.DEVICE_SCAN_MODE_RATE_MS,omitempty"`
+	InitialUpdatesFromCache *bool           `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE,omitempty"`
+}
+
+// SNMPDevice represents an SNMP device.
+type SNMPDevice struct {
+	Device
+
+	Model                      SNMPModel           `json:"servermain.DEVICE_MODEL"`
+	IDFormat                   IDFormat            `json:"servermain.DEVICE_ID_FORMAT"`
+	IDString                   string              `json:"servermain.DEVICE_ID_STRING"`
+	DataCollection             bool                `json:"servermain.DEVICE_DATA_COLLECTION"`
+	Simulated                  bool                `json:"servermain.DEVICE_SIMULATED"`
+	ScanMode                   ScanMode            `json:"servermain.DEVICE_SCAN_MODE"`
+	ScanRate                   int                 `json:"servermain.DEVICE_SCAN_MODE_RATE_MS"`
+	InitialUpdatesFromCache    bool                `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE"`
+	ConnectionTimeout          int                 `json:"servermain.DEVICE_CONNECTION_TIMEOUT_SECONDS"`
+	RequestTimeout             int                 `json:"servermain.DEVICE_REQUEST_TIMEOUT_MILLISECONDS"`
+	AttemptsBeforeTimeout      int                 `json:"servermain.DEVICE_RETRY_ATTEMPTS"`
+	InterRequestDelay          int                 `json:"servermain.DEVICE_INTER_REQUEST_DELAY_MILLISECONDS"`
+	DemoteOnFailure            bool                `json:"servermain.DEVICE_AUTO_DEMOTION_ENABLE_ON_COMMUNICATIONS_FAILURES"`
+	TimeoutToDemote            int                 `json:"servermain.DEVICE_AUTO_DEMOTION_DEMOTE_AFTER_SUCCESSIVE_TIMEOUTS"`
+	DemotionPeriod             int                 `json:"servermain.DEVICE_AUTO_DEMOTION_PERIOD_MS"`
+	DiscardRequestsWhenDemoted bool                `json:"servermain.DEVICE_AUTO_DEMOTION_DISCARD_WRITES"`
+	PortNumber                 int                 `json:"snmp.DEVICE_PORT_NUMBER"`
+	IPProtocol                 Protocol            `json:"snmp.DEVICE_IP_PROTOCOL"`
+	Version                    SNMPVersion         `json:"snmp.DEVICE_SNMP_VERSION"`
+	ReadCommunity              string              `json:"snmp.DEVICE_READ_COMMUNITY"`
+	WriteCommunity             string              `json:"snmp.DEVICE_WRITE_COMMUNITY"`
+	MaxOIDsPerRequest          int                 `json:"snmp.DEVICE_MAX_OIDS_PER_REQUEST"`
+	SecurityLevel              SNMPSecurityLevel   `json:"snmp.DEVICE_SNMPV3_SECURITY_LEVEL"`
+	Username                   string              `json:"snmp.DEVICE_SNMPV3_USERNAME"`
+	ContextName                string              `json:"snmp.DEVICE_SNMPV3_CONTEXT_NAME"`
+	AuthenticationProtocol     SNMPAuthProtocol    `json:"snmp.DEVICE_SNMPV3_AUTHENTICATION_PROTOCOL"`
+	AuthenticationPassphrase   string              `json:"snmp.DEVICE_SNMPV3_AUTHENTICATION_PASSPHRASE"`
+	PrivacyProtocol            SNMPPrivacyProtocol `json:"snmp.DEVICE_SNMPV3_PRIVACY_PROTOCOL"`
+	PrivacyPassphrase          string              `json:"snmp.DEVICE_SNMPV3_PRIVACY_PASSPHRASE"`
+}
+
+// SNMPDeviceOptions represents all SNMP device options.
+type SNMPDeviceOptions struct {
+	Name                       string               `json:"common.ALLTYPES_NAME,omitempty"`
+	Description                string               `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID                  int64                `json:"PROJECT_ID,omitempty"`
+	Driver                     string               `json:"servermain.MULTIPLE_TYPES_DEVICE_DRIVER,omitempty"`
+	Model                      *SNMPModel           `json:"servermain.DEVICE_MODEL,omitempty"`
+	IDFormat                   IDFormat             `json:"servermain.DEVICE_ID_FORMAT,omitempty"`
+	IDString                   string               `json:"servermain.DEVICE_ID_STRING,omitempty"`
+	DataCollection             *bool                `json:"servermain.DEVICE_DATA_COLLECTION,omitempty"`
+	Simulated                  *bool                `json:"servermain.DEVICE_SIMULATED,omitempty"`
+	ScanMode                   *ScanMode            `json:"servermain.DEVICE_SCAN_MODE,omitempty"`
+	ScanRate                   *int                 `json:"servermain.DEVICE_SCAN_MODE_RATE_MS,omitempty"`
+	InitialUpdatesFromCache    *bool                `json:"servermain.DEVICE_SCAN_MODE_PROVIDE_INITIAL_UPDATES_FROM_CACHE,omitempty"`
+	ConnectionTimeout          *int                 `json:"servermain.DEVICE_CONNECTION_TIMEOUT_SECONDS,omitempty"`
+	RequestTimeout             *int                 `json:"servermain.DEVICE_REQUEST_TIMEOUT_MILLISECONDS,omitempty"`
+	AttemptsBeforeTimeout      *int                 `json:"servermain.DEVICE_RETRY_ATTEMPTS,omitempty"`
+	InterRequestDelay          *int                 `json:"servermain.DEVICE_INTER_REQUEST_DELAY_MILLISECONDS,omitempty"`
+	DemoteOnFailure            *bool                `json:"servermain.DEVICE_AUTO_DEMOTION_ENABLE_ON_COMMUNICATIONS_FAILURES,omitempty"`
+	TimeoutToDemote            *int                 `json:"servermain.DEVICE_AUTO_DEMOTION_DEMOTE_AFTER_SUCCESSIVE_TIMEOUTS,omitempty"`
+	DemotionPeriod             *int                 `json:"servermain.DEVICE_AUTO_DEMOTION_PERIOD_MS,omitempty"`
+	DiscardRequestsWhenDemoted *bool                `json:"servermain.DEVICE_AUTO_DEMOTION_DISCARD_WRITES,omitempty"`
+	PortNumber                 *int                 `json:"snmp.DEVICE_PORT_NUMBER,omitempty"`
+	IPProtocol                 *Protocol            `json:"snmp.DEVICE_IP_PROTOCOL,omitempty"`
+	Version                    *SNMPVersion         `json:"snmp.DEVICE_SNMP_VERSION,omitempty"`
+	ReadCommunity              *string              `json:"snmp.DEVICE_READ_COMMUNITY,omitempty"`
+	WriteCommunity             *string              `json:"snmp.DEVICE_WRITE_COMMUNITY,omitempty"`
+	MaxOIDsPerRequest          *int                 `json:"snmp.DEVICE_MAX_OIDS_PER_REQUEST,omitempty"`
+	SecurityLevel              *SNMPSecurityLevel   `json:"snmp.DEVICE_SNMPV3_SECURITY_LEVEL,omitempty"`
+	Username                   *string              `json:"snmp.DEVICE_SNMPV3_USERNAME,omitempty"`
+	ContextName                *string              `json:"snmp.DEVICE_SNMPV3_CONTEXT_NAME,omitempty"`
+	AuthenticationProtocol     *SNMPAuthProtocol    `json:"snmp.DEVICE_SNMPV3_AUTHENTICATION_PROTOCOL,omitempty"`
+	AuthenticationPassphrase   *string              `json:"snmp.DEVICE_SNMPV3_AUTHENTICATION_PASSPHRASE,omitempty"`
+	PrivacyProtocol            *SNMPPrivacyProtocol `json:"snmp.DEVICE_SNMPV3_PRIVACY_PROTOCOL,omitempty"`
+	PrivacyPassphrase          *string              `json:"snmp.DEVICE_SNMPV3_PRIVACY_PASSPHRASE,omitempty"`
+}
+
 // ListDevices gets a list of devices.
-func (s *DeviceService) ListDevices(channel string) ([]*Device, error) {
+func (s *DeviceService) ListDevices(channel string, options ...RequestOptionFunc) ([]*Device, error) {
 	u := fmt.Sprintf("channels/%s/devices", url.PathEscape(channel))
 
-	req, err := s.client.NewRequest("GET", u, nil)
+	req, err := s.client.NewRequest("GET", u, nil, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -379,115 +1143,423 @@ func (s *DeviceService) ListDevices(channel string) ([]*Device, error) {
 	return devices, nil
 }
 
+// ListDeviceConfigs gets a list of devices, each decoded into the device type
+// matching its driver. Devices of drivers without a dedicated device type
+// are returned as a GenericDevice.
+func (s *DeviceService) ListDeviceConfigs(channel string, options ...RequestOptionFunc) ([]DeviceConfig, error) {
+	u := fmt.Sprintf("channels/%s/devices", url.PathEscape(channel))
+
+	req, err := s.client.NewRequest("GET", u, nil, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []json.RawMessage
+	if err = s.client.Do(req, &raw); err != nil {
+		return nil, err
+	}
+
+	devices := make([]DeviceConfig, 0, len(raw))
+	for _, data := range raw {
+		device, err := decodeDeviceConfig(data)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, nil
+}
+
+// CreateBACnetIPDevice creates a new BACnet/IP device.
+func (s *DeviceService) CreateBACnetIPDevice(channel string, opt *BACnetIPDeviceOptions, options ...RequestOptionFunc) error {
+	return s.createDevice(channel, opt, options...)
+}
+
+// CreateBeckhoffTwinCATDevice creates a new Beckhoff TwinCAT device.
+func (s *DeviceService) CreateBeckhoffTwinCATDevice(channel string, opt *BeckhoffTwinCATDeviceOptions, options ...RequestOptionFunc) error {
+	return s.createDevice(channel, opt, options...)
+}
+
 // CreateControlLogixEthernetDevice creates a new ControlLogix Ethernet device.
-func (s *DeviceService) CreateControlLogixEthernetDevice(channel string, options *ControlLogixEthernetDeviceOptions) error {
-	return s.createDevice(channel, options)
+func (s *DeviceService) CreateControlLogixEthernetDevice(channel string, opt *ControlLogixEthernetDeviceOptions, options ...RequestOptionFunc) error {
+	return s.createDevice(channel, opt, options...)
+}
+
+// CreateControlLogixUnsolicitedDevice creates a new ControlLogix Unsolicited device.
+func (s *DeviceService) CreateControlLogixUnsolicitedDevice(channel string, opt *ControlLogixUnsolicitedDeviceOptions, options ...RequestOptionFunc) error {
+	return s.createDevice(channel, opt, options...)
+}
+
+// CreateDevices creates multiple devices at once. Each device must be a
+// GenericDevice or the options of one of the typed devices. Large inputs are
+// created in chunks, see Client.SetBulkChunkSize. A result is returned for
+// every device which creation was attempted.
+func (s *DeviceService) CreateDevices(channel string, devices []interface{}, options ...RequestOptionFunc) ([]*BulkResult, error) {
+	u := fmt.Sprintf("channels/%s/devices", url.PathEscape(channel))
+	return s.client.bulkCreate(u, devices, nil, options)
+}
+
+// CreateDNP3ClientDevice creates a new DNP3 Client device.
+func (s *DeviceService) CreateDNP3ClientDevice(channel string, opt *DNP3ClientDeviceOptions, options ...RequestOptionFunc) error {
+	return s.createDevice(channel, opt, options...)
+}
+
+// CreateGenericDevice creates a new device of any driver using its raw
+// properties. The properties should at least contain a name and a driver.
+func (s *DeviceService) CreateGenericDevice(channel string, device GenericDevice, options ...RequestOptionFunc) error {
+	return s.createDevice(channel, device, options...)
+}
+
+// CreateMicro800EthernetDevice creates a new Micro800 Ethernet device.
+func (s *DeviceService) CreateMicro800EthernetDevice(channel string, opt *Micro800EthernetDeviceOptions, options ...RequestOptionFunc) error {
+	return s.createDevice(channel, opt, options...)
+}
+
+// CreateMitsubishiEthernetDevice creates a new Mitsubishi Ethernet device.
+func (s *DeviceService) CreateMitsubishiEthernetDevice(channel string, opt *MitsubishiEthernetDeviceOptions, options ...RequestOptionFunc) error {
+	return s.createDevice(channel, opt, options...)
+}
+
+// CreateModbusTCPDevice creates a new Modbus TCP/IP Ethernet device.
+func (s *DeviceService) CreateModbusTCPDevice(channel string, opt *ModbusTCPDeviceOptions, options ...RequestOptionFunc) error {
+	return s.createDevice(channel, opt, options...)
+}
+
+// CreateOmronFINSEthernetDevice creates a new Omron FINS Ethernet device.
+func (s *DeviceService) CreateOmronFINSEthernetDevice(channel string, opt *OmronFINSEthernetDeviceOptions, options ...RequestOptionFunc) error {
+	return s.createDevice(channel, opt, options...)
 }
 
 // CreateOPCUAClientDevice creates a new OPC UA client device.
-func (s *DeviceService) CreateOPCUAClientDevice(channel string, options *OPCUAClientDeviceOptions) error {
-	return s.createDevice(channel, options)
+func (s *DeviceService) CreateOPCUAClientDevice(channel string, opt *OPCUAClientDeviceOptions, options ...RequestOptionFunc) error {
+	return s.createDevice(channel, opt, options...)
 }
 
 // CreateSiemensS5AS511Device creates a new Siemens S5 (AS511) device.
-func (s *DeviceService) CreateSiemensS5AS511Device(channel string, options *SiemensS5AS511DeviceOptions) error {
-	return s.createDevice(channel, options)
+func (s *DeviceService) CreateSiemensS5AS511Device(channel string, opt *SiemensS5AS511DeviceOptions, options ...RequestOptionFunc) error {
+	return s.createDevice(channel, opt, options...)
 }
 
 // CreateSiemensTCPIPEthernetDevice creates a new Siemens TCP/IP Ethernet device.
-func (s *DeviceService) CreateSiemensTCPIPEthernetDevice(channel string, options *SiemensTCPIPEthernetDeviceOptions) error {
-	return s.createDevice(channel, options)
+func (s *DeviceService) CreateSiemensTCPIPEthernetDevice(channel string, opt *SiemensTCPIPEthernetDeviceOptions, options ...RequestOptionFunc) error {
+	return s.createDevice(channel, opt, options...)
+}
+
+// CreateSimulatorDevice creates a new Simulator device.
+func (s *DeviceService) CreateSimulatorDevice(channel string, opt *SimulatorDeviceOptions, options ...RequestOptionFunc) error {
+	return s.createDevice(channel, opt, options...)
+}
+
+// CreateSNMPDevice creates a new SNMP device.
+func (s *DeviceService) CreateSNMPDevice(channel string, opt *SNMPDeviceOptions, options ...RequestOptionFunc) error {
+	return s.createDevice(channel, opt, options...)
 }
 
-func (s *DeviceService) createDevice(channel string, v interface{}) error {
+func (s *DeviceService) createDevice(channel string, v interface{}, options ...RequestOptionFunc) error {
 	u := fmt.Sprintf("channels/%s/devices", url.PathEscape(channel))
-	req, err := s.client.NewRequest("POST", u, v)
+	req, err := s.client.NewRequest("POST", u, v, options...)
 	if err != nil {
 		return err
 	}
 	return s.client.Do(req, nil)
 }
 
+// GetBACnetIPDevice gets a BACnet/IP device.
+func (s *DeviceService) GetBACnetIPDevice(channel, name string, options ...RequestOptionFunc) (*BACnetIPDevice, error) {
+	device := new(BACnetIPDevice)
+	if err := s.getDevice(channel, name, device, options...); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// GetBeckhoffTwinCATDevice gets a Beckhoff TwinCAT device.
+func (s *DeviceService) GetBeckhoffTwinCATDevice(channel, name string, options ...RequestOptionFunc) (*BeckhoffTwinCATDevice, error) {
+	device := new(BeckhoffTwinCATDevice)
+	if err := s.getDevice(channel, name, device, options...); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
 // GetControlLogixEthernetDevice gets a ControlLogix Ethernet device.
-func (s *DeviceService) GetControlLogixEthernetDevice(channel, name string) (*ControlLogixEthernetDevice, error) {
-	var device *ControlLogixEthernetDevice
-	v, err := s.getDevice(channel, name, device)
-	if err != nil {
+func (s *DeviceService) GetControlLogixEthernetDevice(channel, name string, options ...RequestOptionFunc) (*ControlLogixEthernetDevice, error) {
+	device := new(ControlLogixEthernetDevice)
+	if err := s.getDevice(channel, name, device, options...); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// GetControlLogixUnsolicitedDevice gets a ControlLogix Unsolicited device.
+func (s *DeviceService) GetControlLogixUnsolicitedDevice(channel, name string, options ...RequestOptionFunc) (*ControlLogixUnsolicitedDevice, error) {
+	device := new(ControlLogixUnsolicitedDevice)
+	if err := s.getDevice(channel, name, device, options...); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// GetDeviceConfig gets a device decoded into the device type matching its
+// driver. Devices of drivers without a dedicated device type are returned
+// as a GenericDevice.
+func (s *DeviceService) GetDeviceConfig(channel, name string, options ...RequestOptionFunc) (DeviceConfig, error) {
+	var data json.RawMessage
+	if err := s.getDevice(channel, name, &data, options...); err != nil {
+		return nil, err
+	}
+	return decodeDeviceConfig(data)
+}
+
+// GetDNP3ClientDevice gets a DNP3 Client device.
+func (s *DeviceService) GetDNP3ClientDevice(channel, name string, options ...RequestOptionFunc) (*DNP3ClientDevice, error) {
+	device := new(DNP3ClientDevice)
+	if err := s.getDevice(channel, name, device, options...); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// GetGenericDevice gets a device of any driver including all its raw properties.
+func (s *DeviceService) GetGenericDevice(channel, name string, options ...RequestOptionFunc) (GenericDevice, error) {
+	var device GenericDevice
+	if err := s.getDevice(channel, name, &device, options...); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// GetMicro800EthernetDevice gets a Micro800 Ethernet device.
+func (s *DeviceService) GetMicro800EthernetDevice(channel, name string, options ...RequestOptionFunc) (*Micro800EthernetDevice, error) {
+	device := new(Micro800EthernetDevice)
+	if err := s.getDevice(channel, name, device, options...); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// GetMitsubishiEthernetDevice gets a Mitsubishi Ethernet device.
+func (s *DeviceService) GetMitsubishiEthernetDevice(channel, name string, options ...RequestOptionFunc) (*MitsubishiEthernetDevice, error) {
+	device := new(MitsubishiEthernetDevice)
+	if err := s.getDevice(channel, name, device, options...); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// GetModbusTCPDevice gets a Modbus TCP/IP Ethernet device.
+func (s *DeviceService) GetModbusTCPDevice(channel, name string, options ...RequestOptionFunc) (*ModbusTCPDevice, error) {
+	device := new(ModbusTCPDevice)
+	if err := s.getDevice(channel, name, device, options...); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// GetOmronFINSEthernetDevice gets an Omron FINS Ethernet device.
+func (s *DeviceService) GetOmronFINSEthernetDevice(channel, name string, options ...RequestOptionFunc) (*OmronFINSEthernetDevice, error) {
+	device := new(OmronFINSEthernetDevice)
+	if err := s.getDevice(channel, name, device, options...); err != nil {
 		return nil, err
 	}
-	return v.(*ControlLogixEthernetDevice), nil
+	return device, nil
 }
 
 // GetOPCUAClientDevice gets an OPC UA client device.
-func (s *DeviceService) GetOPCUAClientDevice(channel, name string) (*OPCUAClientDevice, error) {
-	var device *OPCUAClientDevice
-	v, err := s.getDevice(channel, name, device)
-	if err != nil {
+func (s *DeviceService) GetOPCUAClientDevice(channel, name string, options ...RequestOptionFunc) (*OPCUAClientDevice, error) {
+	device := new(OPCUAClientDevice)
+	if err := s.getDevice(channel, name, device, options...); err != nil {
 		return nil, err
 	}
-	return v.(*OPCUAClientDevice), nil
+	return device, nil
 }
 
 // GetSiemensS5AS511Device gets an Siemens S5 (AS511) device.
-func (s *DeviceService) GetSiemensS5AS511Device(channel, name string) (*SiemensS5AS511Device, error) {
-	var device *SiemensS5AS511Device
-	v, err := s.getDevice(channel, name, device)
-	if err != nil {
+func (s *DeviceService) GetSiemensS5AS511Device(channel, name string, options ...RequestOptionFunc) (*SiemensS5AS511Device, error) {
+	device := new(SiemensS5AS511Device)
+	if err := s.getDevice(channel, name, device, options...); err != nil {
 		return nil, err
 	}
-	return v.(*SiemensS5AS511Device), nil
+	return device, nil
 }
 
 // GetSiemensTCPIPEthernetDevice gets an Siemens TCP/IP Ethernet device.
-func (s *DeviceService) GetSiemensTCPIPEthernetDevice(channel, name string) (*SiemensTCPIPEthernetDevice, error) {
-	var device *SiemensTCPIPEthernetDevice
-	v, err := s.getDevice(channel, name, device)
-	if err != nil {
+func (s *DeviceService) GetSiemensTCPIPEthernetDevice(channel, name string, options ...RequestOptionFunc) (*SiemensTCPIPEthernetDevice, error) {
+	device := new(SiemensTCPIPEthernetDevice)
+	if err := s.getDevice(channel, name, device, options...); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// GetSimulatorDevice gets a Simulator device.
+func (s *DeviceService) GetSimulatorDevice(channel, name string, options ...RequestOptionFunc) (*SimulatorDevice, error) {
+	device := new(SimulatorDevice)
+	if err := s.getDevice(channel, name, device, options...); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// GetSNMPDevice gets an SNMP device.
+func (s *DeviceService) GetSNMPDevice(channel, name string, options ...RequestOptionFunc) (*SNMPDevice, error) {
+	device := new(SNMPDevice)
+	if err := s.getDevice(channel, name, device, options...); err != nil {
 		return nil, err
 	}
-	return v.(*SiemensTCPIPEthernetDevice), nil
+	return device, nil
 }
 
 // getDevice gets a specific device.
-func (s *DeviceService) getDevice(channel, name string, v interface{}) (interface{}, error) {
+func (s *DeviceService) getDevice(channel, name string, v interface{}, options ...RequestOptionFunc) error {
 	u := fmt.Sprintf("channels/%s/devices/%s", url.PathEscape(channel), url.PathEscape(name))
 
-	req, err := s.client.NewRequest("GET", u, v)
+	req, err := s.client.NewRequest("GET", u, nil, options...)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Do(req, v)
+}
+
+// decodeDeviceConfig decodes a device into the type matching its driver.
+func decodeDeviceConfig(data []byte) (DeviceConfig, error) {
+	var info Device
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+
+	newDevice, ok := deviceTypes[info.Driver]
+	if !ok {
+		var device GenericDevice
+		if err := json.Unmarshal(data, &device); err != nil {
+			return nil, err
+		}
+		return device, nil
+	}
+
+	device := newDevice()
+	if err := json.Unmarshal(data, device); err != nil {
+		return nil, err
+	}
+
+	return device, nil
+}
+
+// GetDeviceStatistics gets the runtime statistics of a device.
+func (s *DeviceService) GetDeviceStatistics(channel, name string, options ...RequestOptionFunc) (*Statistics, error) {
+	u := fmt.Sprintf("channels/%s/devices/%s/_statistics", url.PathEscape(channel), url.PathEscape(name))
+
+	req, err := s.client.NewRequest("GET", u, nil, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := new(Statistics)
+	if err = s.client.Do(req, stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetDeviceStatus gets the runtime status of a device.
+func (s *DeviceService) GetDeviceStatus(channel, name string, options ...RequestOptionFunc) (*DeviceStatus, error) {
+	u := fmt.Sprintf("channels/%s/devices/%s/_system", url.PathEscape(channel), url.PathEscape(name))
+
+	req, err := s.client.NewRequest("GET", u, nil, options...)
 	if err != nil {
 		return nil, err
 	}
 
-	var c *Device
-	if err = s.client.Do(req, c); err != nil {
+	status := new(DeviceStatus)
+	if err = s.client.Do(req, status); err != nil {
 		return nil, err
 	}
 
-	return c, nil
+	return status, nil
+}
+
+// UpdateBACnetIPDevice updates an existing BACnet/IP device.
+func (s *DeviceService) UpdateBACnetIPDevice(channel, name string, opt *BACnetIPDeviceOptions, options ...RequestOptionFunc) error {
+	return s.updateDevice(channel, name, opt, options...)
+}
+
+// UpdateBeckhoffTwinCATDevice updates an existing Beckhoff TwinCAT device.
+func (s *DeviceService) UpdateBeckhoffTwinCATDevice(channel, name string, opt *BeckhoffTwinCATDeviceOptions, options ...RequestOptionFunc) error {
+	return s.updateDevice(channel, name, opt, options...)
 }
 
 // UpdateControlLogixEthernetDevice updates an existing ControlLogix Ethernet device.
-func (s *DeviceService) UpdateControlLogixEthernetDevice(channel string, options *ControlLogixEthernetDevice) error {
-	return s.createDevice(channel, options)
+func (s *DeviceService) UpdateControlLogixEthernetDevice(channel, name string, opt *ControlLogixEthernetDeviceOptions, options ...RequestOptionFunc) error {
+	return s.updateDevice(channel, name, opt, options...)
+}
+
+// UpdateControlLogixUnsolicitedDevice updates an existing ControlLogix Unsolicited device.
+func (s *DeviceService) UpdateControlLogixUnsolicitedDevice(channel, name string, opt *ControlLogixUnsolicitedDeviceOptions, options ...RequestOptionFunc) error {
+	return s.updateDevice(channel, name, opt, options...)
+}
+
+// UpdateDNP3ClientDevice updates an existing DNP3 Client device.
+func (s *DeviceService) UpdateDNP3ClientDevice(channel, name string, opt *DNP3ClientDeviceOptions, options ...RequestOptionFunc) error {
+	return s.updateDevice(channel, name, opt, options...)
+}
+
+// UpdateGenericDevice updates an existing device of any driver. Only the
+// properties contained in the given device are updated.
+func (s *DeviceService) UpdateGenericDevice(channel, name string, device GenericDevice, options ...RequestOptionFunc) error {
+	return s.updateDevice(channel, name, device, options...)
+}
+
+// UpdateMicro800EthernetDevice updates an existing Micro800 Ethernet device.
+func (s *DeviceService) UpdateMicro800EthernetDevice(channel, name string, opt *Micro800EthernetDeviceOptions, options ...RequestOptionFunc) error {
+	return s.updateDevice(channel, name, opt, options...)
+}
+
+// UpdateMitsubishiEthernetDevice updates an existing Mitsubishi Ethernet device.
+func (s *DeviceService) UpdateMitsubishiEthernetDevice(channel, name string, opt *MitsubishiEthernetDeviceOptions, options ...RequestOptionFunc) error {
+	return s.updateDevice(channel, name, opt, options...)
+}
+
+// UpdateModbusTCPDevice updates an existing Modbus TCP/IP Ethernet device.
+func (s *DeviceService) UpdateModbusTCPDevice(channel, name string, opt *ModbusTCPDeviceOptions, options ...RequestOptionFunc) error {
+	return s.updateDevice(channel, name, opt, options...)
+}
+
+// UpdateOmronFINSEthernetDevice updates an existing Omron FINS Ethernet device.
+func (s *DeviceService) UpdateOmronFINSEthernetDevice(channel, name string, opt *OmronFINSEthernetDeviceOptions, options ...RequestOptionFunc) error {
+	return s.updateDevice(channel, name, opt, options...)
 }
 
 // UpdateOPCUAClientDevice updates an existing OPC UA client device.
-func (s *DeviceService) UpdateOPCUAClientDevice(channel, name string, options *OPCUAClientDeviceOptions) error {
-	return s.updateDevice(channel, name, options)
+func (s *DeviceService) UpdateOPCUAClientDevice(channel, name string, opt *OPCUAClientDeviceOptions, options ...RequestOptionFunc) error {
+	return s.updateDevice(channel, name, opt, options...)
 }
 
 // UpdateSiemensS5AS511Device updates an existing Siemens S5 (AS511) device.
-func (s *DeviceService) UpdateSiemensS5AS511Device(channel, name string, options *SiemensS5AS511DeviceOptions) error {
-	return s.updateDevice(channel, name, options)
+func (s *DeviceService) UpdateSiemensS5AS511Device(channel, name string, opt *SiemensS5AS511DeviceOptions, options ...RequestOptionFunc) error {
+	return s.updateDevice(channel, name, opt, options...)
 }
 
 // UpdateSiemensTCPIPEthernetDevice updates an existing Siemens TCP/IP Ethernet device.
-func (s *DeviceService) UpdateSiemensTCPIPEthernetDevice(channel, name string, options *SiemensTCPIPEthernetDeviceOptions) error {
-	return s.updateDevice(channel, name, options)
+func (s *DeviceService) UpdateSiemensTCPIPEthernetDevice(channel, name string, opt *SiemensTCPIPEthernetDeviceOptions, options ...RequestOptionFunc) error {
+	return s.updateDevice(channel, name, opt, options...)
+}
+
+// UpdateSimulatorDevice updates an existing Simulator device.
+func (s *DeviceService) UpdateSimulatorDevice(channel, name string, opt *SimulatorDeviceOptions, options ...RequestOptionFunc) error {
+	return s.updateDevice(channel, name, opt, options...)
+}
+
+// UpdateSNMPDevice updates an existing SNMP device.
+func (s *DeviceService) UpdateSNMPDevice(channel, name string, opt *SNMPDeviceOptions, options ...RequestOptionFunc) error {
+	return s.updateDevice(channel, name, opt, options...)
 }
 
-func (s *DeviceService) updateDevice(channel, name string, v interface{}) error {
+func (s *DeviceService) updateDevice(channel, name string, v interface{}, options ...RequestOptionFunc) error {
 	u := fmt.Sprintf("channels/%s/devices/%s", url.PathEscape(channel), url.PathEscape(name))
-	req, err := s.client.NewRequest("PUT", u, v)
+	req, err := s.client.NewRequest("PUT", u, v, options...)
 	if err != nil {
 		return err
 	}
@@ -495,9 +1567,9 @@ func (s *DeviceService) updateDevice(channel, name string, v interface{}) error
 }
 
 // DeleteDevice deletes a device.
-func (s *DeviceService) DeleteDevice(channel, name string) error {
+func (s *DeviceService) DeleteDevice(channel, name string, options ...RequestOptionFunc) error {
 	u := fmt.Sprintf("channels/%s/devices/%s", url.PathEscape(channel), url.PathEscape(name))
-	req, err := s.client.NewRequest("DELETE", u, nil)
+	req, err := s.client.NewRequest("DELETE", u, nil, options...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,279 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeDeviceConfig(t *testing.T) {
+	info := Device{
+		Name:              "Device1",
+		Description:       "Test device",
+		UniqueID:          1234,
+		ProjectID:         42,
+		ChannelAssignment: "Channel1",
+	}
+
+	for driver, newDevice := range deviceTypes {
+		info.Driver = driver
+
+		data, err := json.Marshal(info)
+		if err != nil {
+			t.Fatalf("failed to marshal device: %v", err)
+		}
+
+		device, err := decodeDeviceConfig(data)
+		if err != nil {
+			t.Fatalf("decodeDeviceConfig returned error for %s: %v", driver, err)
+		}
+
+		want := newDevice()
+		if reflect.TypeOf(device) != reflect.TypeOf(want) {
+			t.Errorf("decodeDeviceConfig returned a %T for %s, want a %T", device, driver, want)
+		}
+		if got := device.DeviceInfo(); *got != info {
+			t.Errorf("DeviceInfo of %T returned %+v, want %+v", device, *got, info)
+		}
+
+		// The shared properties must be marshaled as top-level properties.
+		data, err = json.Marshal(device)
+		if err != nil {
+			t.Fatalf("failed to marshal %T: %v", device, err)
+		}
+		var props map[string]interface{}
+		if err := json.Unmarshal(data, &props); err != nil {
+			t.Fatalf("failed to unmarshal %T: %v", device, err)
+		}
+		if props[nameProperty] != "Device1" || props["servermain.MULTIPLE_TYPES_DEVICE_DRIVER"] != driver {
+			t.Errorf("%T marshaled to %s, want the shared properties at the top level", device, data)
+		}
+	}
+
+	data := []byte(`{"common.ALLTYPES_NAME":"Device1","servermain.MULTIPLE_TYPES_DEVICE_DRIVER":"Unknown Driver","unknown.DEVICE_SETTING":1}`)
+	device, err := decodeDeviceConfig(data)
+	if err != nil {
+		t.Fatalf("decodeDeviceConfig returned error: %v", err)
+	}
+	if _, ok := device.(GenericDevice); !ok {
+		t.Fatalf("decodeDeviceConfig returned a %T, want a GenericDevice", device)
+	}
+	if got := device.DeviceInfo(); got.Name != "Device1" || got.Driver != "Unknown Driver" {
+		t.Errorf("DeviceInfo returned %+v", *got)
+	}
+}
+
+// The fixtures in testdata are representative device payloads as returned by
+// the KEPServerEX Configuration API.
+func TestDeviceFixtures(t *testing.T) {
+	tests := []struct {
+		file    string
+		prefix  string
+		device  DeviceConfig
+		options interface{}
+		check   func(DeviceConfig) bool
+	}{
+		{
+			file:    "omron_fins_ethernet_device.json",
+			prefix:  "omron_fins_ethernet.",
+			device:  new(OmronFINSEthernetDevice),
+			options: new(OmronFINSEthernetDeviceOptions),
+			check: func(v DeviceConfig) bool {
+				d := v.(*OmronFINSEthernetDevice)
+				return d.Model == FINS_CJ1 && d.IPProtocol == UDP &&
+					d.PortNumber == 9600 && d.DestinationNode == 20
+			},
+		},
+		{
+			file:    "mitsubishi_ethernet_device.json",
+			prefix:  "mitsubishi_ethernet.",
+			device:  new(MitsubishiEthernetDevice),
+			options: new(MitsubishiEthernetDeviceOptions),
+			check: func(v DeviceConfig) bool {
+				d := v.(*MitsubishiEthernetDevice)
+				return d.Model == Q_Series && d.IPProtocol == TCPIP &&
+					d.CPU == MitsubishiCPU_Local && d.WordOrder == WordOrder_LowFirst
+			},
+		},
+		{
+			file:    "beckhoff_twincat_device.json",
+			prefix:  "beckhoff_twincat.",
+			device:  new(BeckhoffTwinCATDevice),
+			options: new(BeckhoffTwinCATDeviceOptions),
+			check: func(v DeviceConfig) bool {
+				d := v.(*BeckhoffTwinCATDevice)
+				return d.Model == TwinCAT_3 && d.RuntimePort == 851 &&
+					d.TagHierarchy == Expanded && d.SourceAMSNetID == "192.168.30.10.1.1"
+			},
+		},
+		{
+			file:    "micro800_ethernet_device.json",
+			prefix:  "micro800_ethernet.",
+			device:  new(Micro800EthernetDevice),
+			options: new(Micro800EthernetDeviceOptions),
+			check: func(v DeviceConfig) bool {
+				d := v.(*Micro800EthernetDevice)
+				return d.Model == Micro800 && d.TCPIPPort == 44818 &&
+					d.InactivityWatchdog == InactivityWatchdog_32 && d.ArrayBlockSize == ArrayBlockSize_120 &&
+					d.DefaultDataType == DataType_Default && d.OnDeviceStartup == GenerateOnFirstStartup
+			},
+		},
+		{
+			file:    "controllogix_unsolicited_device.json",
+			prefix:  "controllogix_unsolicited.",
+			device:  new(ControlLogixUnsolicitedDevice),
+			options: new(ControlLogixUnsolicitedDeviceOptions),
+			check: func(v DeviceConfig) bool {
+				d := v.(*ControlLogixUnsolicitedDevice)
+				return d.Model == Unsolicited_ControlLogix && d.UnsolicitedTimeout == 30 &&
+					d.ValidateSourceAddress && d.DefaultDataType == DataType_DWord
+			},
+		},
+		{
+			file:    "bacnet_ip_device.json",
+			prefix:  "bacnet.",
+			device:  new(BACnetIPDevice),
+			options: new(BACnetIPDeviceOptions),
+			check: func(v DeviceConfig) bool {
+				d := v.(*BACnetIPDevice)
+				return d.Model == BACnet && d.IDString == "1001" &&
+					d.MaxAPDULength == BACnetMaxAPDU_1476 && d.Segmentation == BACnetSegmentation_Both &&
+					d.COVMode == BACnetCOVMode_Confirmed && d.COVResubscriptionInterval == 3600
+			},
+		},
+		{
+			file:    "snmp_device.json",
+			prefix:  "snmp.",
+			device:  new(SNMPDevice),
+			options: new(SNMPDeviceOptions),
+			check: func(v DeviceConfig) bool {
+				d := v.(*SNMPDevice)
+				return d.Model == SNMPAgent && d.PortNumber == 161 && d.IPProtocol == UDP &&
+					d.Version == SNMPVersion_V3 && d.SecurityLevel == SNMPSecurityLevel_AuthPriv &&
+					d.AuthenticationProtocol == SNMPAuthProtocol_SHA && d.PrivacyProtocol == SNMPPrivacyProtocol_AES128 &&
+					d.Username == "monitor"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		data, err := ioutil.ReadFile(filepath.Join("testdata", tt.file))
+		if err != nil {
+			t.Fatalf("failed to read fixture: %v", err)
+		}
+
+		var fixture map[string]interface{}
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			t.Fatalf("failed to unmarshal %s: %v", tt.file, err)
+		}
+
+		device, err := decodeDeviceConfig(data)
+		if err != nil {
+			t.Fatalf("decodeDeviceConfig returned error for %s: %v", tt.file, err)
+		}
+		if reflect.TypeOf(device) != reflect.TypeOf(tt.device) {
+			t.Fatalf("decodeDeviceConfig returned a %T for %s, want a %T", device, tt.file, tt.device)
+		}
+		if !tt.check(device) {
+			t.Errorf("%s decoded to unexpected values: %+v", tt.file, device)
+		}
+
+		// The device must marshal to exactly the properties of the fixture,
+		// except for the ones which are not modelled.
+		got := roundTrip(t, data, tt.device)
+		for k, v := range fixture {
+			if k == "servermain.DEVICE_STATIC_TAG_COUNT" {
+				continue
+			}
+			if !reflect.DeepEqual(got[k], v) {
+				t.Errorf("%T marshaled %s as %v, want %v", tt.device, k, got[k], v)
+			}
+		}
+		for k := range got {
+			if _, ok := fixture[k]; !ok {
+				t.Errorf("%T marshaled unknown property %s", tt.device, k)
+			}
+		}
+
+		// The options must keep all properties they model, including the
+		// ones set to false or zero, and model all driver properties.
+		got = roundTrip(t, data, tt.options)
+		for k, v := range got {
+			if !reflect.DeepEqual(fixture[k], v) {
+				t.Errorf("%T marshaled %s as %v, want %v", tt.options, k, v, fixture[k])
+			}
+		}
+		for k := range fixture {
+			if _, ok := got[k]; !ok && strings.HasPrefix(k, tt.prefix) {
+				t.Errorf("%T did not marshal %s", tt.options, k)
+			}
+		}
+	}
+}
+
+// roundTrip unmarshals data into v and returns the properties v marshals to.
+func roundTrip(t *testing.T, data []byte, v interface{}) map[string]interface{} {
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("failed to unmarshal %T: %v", v, err)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal %T: %v", v, err)
+	}
+	var props map[string]interface{}
+	if err := json.Unmarshal(data, &props); err != nil {
+		t.Fatalf("failed to unmarshal %T: %v", v, err)
+	}
+	return props
+}
+
+func TestWordOrderJSON(t *testing.T) {
+	tests := []struct {
+		order WordOrder
+		json  string
+	}{
+		{WordOrder_HighFirst, "false"},
+		{WordOrder_LowFirst, "true"},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.order)
+		if err != nil || string(data) != tt.json {
+			t.Errorf("json.Marshal(%d) returned %s, %v, want %s", tt.order, data, err, tt.json)
+		}
+
+		var order WordOrder
+		if err := json.Unmarshal([]byte(tt.json), &order); err != nil || order != tt.order {
+			t.Errorf("json.Unmarshal(%s) returned %d, %v, want %d", tt.json, order, err, tt.order)
+		}
+	}
+
+	// A pointer to the zero value must still be sent.
+	order := WordOrder_HighFirst
+	data, err := json.Marshal(&ModbusTCPDeviceOptions{WordOrder: &order})
+	if err != nil {
+		t.Fatalf("failed to marshal options: %v", err)
+	}
+	if want := `"modbus_tcpip_ethernet.DEVICE_FIRST_WORD_LOW":false`; !strings.Contains(string(data), want) {
+		t.Errorf("ModbusTCPDeviceOptions marshaled to %s, want it to contain %s", data, want)
+	}
+}
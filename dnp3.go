@@ -0,0 +1,168 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DNP3Address represents the address of a DNP3 Client tag, made up of the
+// object group, variation and index of a point and the field to access.
+type DNP3Address struct {
+	Group     DNP3Group
+	Variation int
+	Index     int
+
+	// Field is the sub-type of the point to access (e.g. Value, Flags or
+	// Timestamp). Defaults to Value when empty.
+	Field string
+}
+
+// ParseDNP3Address parses an address like "30.0.5.Value".
+func ParseDNP3Address(s string) (*DNP3Address, error) {
+	parts := strings.SplitN(s, ".", 4)
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("invalid DNP3 address %q", s)
+	}
+
+	nums := make([]int, 3)
+	for i := range nums {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid DNP3 address %q", s)
+		}
+		nums[i] = n
+	}
+
+	a := &DNP3Address{
+		Group:     DNP3Group(nums[0]),
+		Variation: nums[1],
+		Index:     nums[2],
+	}
+	if len(parts) == 4 {
+		if !dnp3Fields[parts[3]] {
+			return nil, fmt.Errorf("invalid DNP3 address %q: unknown field %q", s, parts[3])
+		}
+		a.Field = parts[3]
+	}
+
+	return a, nil
+}
+
+// dnp3Fields contains the fields (sub-types) of a point which can be
+// accessed using a DNP3 Client tag.
+var dnp3Fields = map[string]bool{
+	"Value":                 true,
+	"Flags":                 true,
+	"Timestamp":             true,
+	"Online":                true,
+	"Restart":               true,
+	"CommLost":              true,
+	"RemoteForced":          true,
+	"LocalForced":           true,
+	"ChatterFilter":         true,
+	"Overrange":             true,
+	"ReferenceCheck":        true,
+	"Rollover":              true,
+	"Discontinuity":         true,
+	"State":                 true,
+	"DeadBand":              true,
+	"Explicit":              true,
+	"Operate":               true,
+	"Operate.OpType":        true,
+	"Operate.TripCloseCode": true,
+	"Operate.Clear":         true,
+	"Operate.OnTime":        true,
+	"Operate.OffTime":       true,
+	"Operate.Feedback":      true,
+	"Operate.Set":           true,
+}
+
+// dnp3Variations maps the object groups supporting values of different
+// sizes to the data type of the value of each variation. Variation 0
+// requests the default variation, which is 32-bit for all of them.
+var dnp3Variations = map[DNP3Group]map[int]DataType{
+	DNP3Group_Counter: {
+		0: DataType_DWord, 1: DataType_DWord, 2: DataType_Word, 5: DataType_DWord, 6: DataType_Word,
+	},
+	DNP3Group_FrozenCounter: {
+		0: DataType_DWord, 1: DataType_DWord, 2: DataType_Word, 5: DataType_DWord, 6: DataType_Word,
+		9: DataType_DWord, 10: DataType_Word,
+	},
+	DNP3Group_AnalogInput: {
+		0: DataType_Long, 1: DataType_Long, 2: DataType_Short, 3: DataType_Long, 4: DataType_Short,
+		5: DataType_Float, 6: DataType_Double,
+	},
+	DNP3Group_AnalogOutputStatus: {
+		0: DataType_Long, 1: DataType_Long, 2: DataType_Short, 3: DataType_Float, 4: DataType_Double,
+	},
+	DNP3Group_AnalogOutput: {
+		0: DataType_Long, 1: DataType_Long, 2: DataType_Short, 3: DataType_Float, 4: DataType_Double,
+	},
+}
+
+// String returns the address as used by the DNP3 Client driver.
+func (a *DNP3Address) String() string {
+	field := a.Field
+	if field == "" {
+		field = "Value"
+	}
+	return fmt.Sprintf("%d.%d.%d.%s", a.Group, a.Variation, a.Index, field)
+}
+
+// DataType returns the data type matching the value of the addressed point.
+func (a *DNP3Address) DataType() DataType {
+	switch a.Field {
+	case "", "Value":
+	case "Timestamp":
+		return DataType_Date
+	default:
+		return DataType_Default
+	}
+
+	switch a.Group {
+	case DNP3Group_BinaryInput, DNP3Group_BinaryOutput, DNP3Group_BinaryCommand:
+		return DataType_Boolean
+	case DNP3Group_DoubleBitInput:
+		return DataType_Byte
+	case DNP3Group_Counter, DNP3Group_FrozenCounter, DNP3Group_AnalogInput,
+		DNP3Group_AnalogOutputStatus, DNP3Group_AnalogOutput:
+		if dataType, ok := dnp3Variations[a.Group][a.Variation]; ok {
+			return dataType
+		}
+		return DataType_Default
+	case DNP3Group_TimeAndDate:
+		return DataType_Date
+	case DNP3Group_OctetString:
+		return DataType_String
+	default:
+		return DataType_Default
+	}
+}
+
+// DNP3Tag returns the options for a DNP3 Client tag with the given address
+// and a data type matching the addressed point.
+func DNP3Tag(name string, addr *DNP3Address) *TagOptions {
+	dataType := addr.DataType()
+	return &TagOptions{
+		Name:     String(name),
+		Address:  String(addr.String()),
+		DataType: &dataType,
+	}
+}
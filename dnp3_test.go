@@ -0,0 +1,117 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import "testing"
+
+func TestParseDNP3Address(t *testing.T) {
+	tests := []struct {
+		addr    string
+		want    DNP3Address
+		wantErr bool
+	}{
+		{addr: "30.0.5", want: DNP3Address{Group: DNP3Group_AnalogInput, Index: 5}},
+		{addr: "30.5.1.Value", want: DNP3Address{Group: DNP3Group_AnalogInput, Variation: 5, Index: 1, Field: "Value"}},
+		{addr: "1.0.0.Timestamp", want: DNP3Address{Group: DNP3Group_BinaryInput, Field: "Timestamp"}},
+		{addr: "12.1.3.Operate.OnTime", want: DNP3Address{Group: DNP3Group_BinaryCommand, Variation: 1, Index: 3, Field: "Operate.OnTime"}},
+		{addr: "30.0", wantErr: true},
+		{addr: "30.x.5", wantErr: true},
+		{addr: "30.0.-1", wantErr: true},
+		{addr: "30.0.5.Bogus", wantErr: true},
+		{addr: "30.0.5.value", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDNP3Address(tt.addr)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseDNP3Address(%q) returned %+v, want an error", tt.addr, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseDNP3Address(%q) returned error: %v", tt.addr, err)
+			continue
+		}
+		if *got != tt.want {
+			t.Errorf("ParseDNP3Address(%q) returned %+v, want %+v", tt.addr, *got, tt.want)
+		}
+	}
+}
+
+func TestDNP3AddressString(t *testing.T) {
+	a := &DNP3Address{Group: DNP3Group_AnalogInput, Index: 5}
+	if got, want := a.String(), "30.0.5.Value"; got != want {
+		t.Errorf("String returned %q, want %q", got, want)
+	}
+}
+
+func TestDNP3AddressDataType(t *testing.T) {
+	tests := []struct {
+		addr string
+		want DataType
+	}{
+		{"1.0.0", DataType_Boolean},
+		{"3.0.0", DataType_Byte},
+		{"10.0.0", DataType_Boolean},
+		{"12.1.0", DataType_Boolean},
+
+		{"20.0.0", DataType_DWord},
+		{"20.1.0", DataType_DWord},
+		{"20.2.0", DataType_Word},
+		{"20.5.0", DataType_DWord},
+		{"20.6.0", DataType_Word},
+		{"21.9.0", DataType_DWord},
+		{"21.10.0", DataType_Word},
+
+		{"30.0.0", DataType_Long},
+		{"30.1.0", DataType_Long},
+		{"30.2.0", DataType_Short},
+		{"30.3.0", DataType_Long},
+		{"30.4.0", DataType_Short},
+		{"30.5.0", DataType_Float},
+		{"30.6.0", DataType_Double},
+		{"30.7.0", DataType_Default},
+
+		{"40.0.0", DataType_Long},
+		{"40.1.0", DataType_Long},
+		{"40.2.0", DataType_Short},
+		{"40.3.0", DataType_Float},
+		{"40.4.0", DataType_Double},
+		{"40.5.0", DataType_Default},
+
+		{"41.1.0", DataType_Long},
+		{"41.2.0", DataType_Short},
+		{"41.3.0", DataType_Float},
+		{"41.4.0", DataType_Double},
+
+		{"50.1.0", DataType_Date},
+		{"110.0.0", DataType_String},
+		{"30.5.0.Timestamp", DataType_Date},
+		{"30.5.0.Flags", DataType_Default},
+	}
+
+	for _, tt := range tests {
+		a, err := ParseDNP3Address(tt.addr)
+		if err != nil {
+			t.Fatalf("ParseDNP3Address(%q) returned error: %v", tt.addr, err)
+		}
+		if got := a.DataType(); got != tt.want {
+			t.Errorf("DataType of %q is %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EventLogService handles communication with the event log related
+// methods of the KEPServerEX API.
+type EventLogService struct {
+	client *Client
+}
+
+// EventType represents the type (severity) of an event.
+type EventType string
+
+// List of available event types.
+const (
+	EventType_Information EventType = "Information"
+	EventType_Warning     EventType = "Warning"
+	EventType_Error       EventType = "Error"
+	EventType_Security    EventType = "Security"
+)
+
+// Event represents a KEPServerEX event log entry.
+type Event struct {
+	Timestamp Timestamp `json:"timestamp"`
+	Type      EventType `json:"event"`
+	Source    string    `json:"source"`
+	Message   string    `json:"message"`
+}
+
+// ListEventsOptions represents the available ListEvents options.
+type ListEventsOptions struct {
+	Limit *int       `url:"limit,omitempty"`
+	Start *Timestamp `url:"start,omitempty"`
+	End   *Timestamp `url:"end,omitempty"`
+}
+
+// ListEvents gets a list of event log entries, sorted from oldest to newest.
+func (s *EventLogService) ListEvents(opt *ListEventsOptions, options ...RequestOptionFunc) ([]*Event, error) {
+	req, err := s.client.newRootRequest("GET", "event_log", opt, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*Event
+	if err = s.client.Do(req, &events); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp.Time)
+	})
+
+	return events, nil
+}
+
+// FollowEventsOptions represents the available FollowEvents options.
+type FollowEventsOptions struct {
+	// Interval between two polls. Defaults to 5 seconds.
+	Interval time.Duration
+
+	// Since only follows events logged at or after the given time.
+	// Defaults to the current time.
+	Since time.Time
+
+	// Limit is the maximum number of events requested per poll.
+	// Defaults to 1000.
+	Limit int
+}
+
+// FollowEvents polls the event log for new events until the context is
+// done, delivering them in order on the returned events channel. Both
+// channels are closed when the context is done.
+//
+// A failed poll does not stop following; the error is sent on the returned
+// error channel and polling continues at the next interval. Errors are sent
+// without blocking, so an error is dropped when the previous one was not
+// received yet.
+//
+// The event log can only be queried by time, so when a single millisecond
+// contains more events than the limit, the events beyond the limit cannot be
+// retrieved and are skipped. Use a limit well above the number of events
+// logged in a millisecond to prevent this.
+func (s *EventLogService) FollowEvents(ctx context.Context, opt *FollowEventsOptions, options ...RequestOptionFunc) (<-chan *Event, <-chan error) {
+	if opt == nil {
+		opt = new(FollowEventsOptions)
+	}
+	interval := opt.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	limit := opt.Limit
+	if limit <= 0 {
+		limit = 1000
+	}
+	since := opt.Since
+	if since.IsZero() {
+		since = time.Now()
+	}
+	options = append(options[:len(options):len(options)], WithContext(ctx))
+
+	events := make(chan *Event)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		// The start filter is inclusive, so remember the events seen at
+		// the last timestamp to prevent delivering them twice.
+		start := Timestamp{since}
+		seen := make(map[Event]bool)
+
+		for {
+			list, err := s.ListEvents(&ListEventsOptions{
+				Limit: Int(limit),
+				Start: &start,
+			}, options...)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				select {
+				case errs <- err:
+				default:
+				}
+				list = nil
+			}
+
+			delivered := 0
+			for _, e := range list {
+				if seen[*e] {
+					continue
+				}
+				if !e.Timestamp.Equal(start.Time) {
+					start = e.Timestamp
+					seen = make(map[Event]bool)
+				}
+				seen[*e] = true
+
+				select {
+				case events <- e:
+					delivered++
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			// Poll again right away if the limit was reached. If all events
+			// were seen before, they all share the last timestamp and any
+			// others at that timestamp are beyond the limit, so skip past
+			// it to prevent getting the same events over and over.
+			if len(list) == limit {
+				if delivered == 0 {
+					start = Timestamp{start.Add(time.Millisecond)}
+					seen = make(map[Event]bool)
+				}
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return events, errs
+}
+
+// timestampLayout is the layout KEPServerEX uses for log timestamps.
+const timestampLayout = "2006-01-02T15:04:05.000"
+
+// Timestamp represents a KEPServerEX log timestamp. Log timestamps are
+// formatted as ISO 8601 in UTC, with millisecond precision.
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp returns a pointer to a Timestamp for the given time.
+func NewTimestamp(t time.Time) *Timestamp {
+	return &Timestamp{t}
+}
+
+// String returns the timestamp formatted like KEPServerEX does.
+func (t Timestamp) String() string {
+	return t.UTC().Format(timestampLayout)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + t.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		parsed, err = time.ParseInLocation(timestampLayout, s, time.UTC)
+		if err != nil {
+			return err
+		}
+	}
+	t.Time = parsed
+
+	return nil
+}
+
+// EncodeValues implements the query.Encoder interface.
+func (t Timestamp) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, t.String())
+	return nil
+}
@@ -0,0 +1,205 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	kepserverex "github.com/svanharmelen/go-kepserverex"
+	"github.com/svanharmelen/go-kepserverex/kepserverextest"
+)
+
+// receiveEvents receives n events, failing the test when they do not arrive
+// in time.
+func receiveEvents(t *testing.T, events <-chan *kepserverex.Event, n int) []string {
+	var got []string
+	for i := 0; i < n; i++ {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				t.Fatalf("events channel closed after %v", got)
+			}
+			got = append(got, e.Message)
+		case <-time.After(time.Second):
+			t.Fatalf("received %v, want %d events", got, n)
+		}
+	}
+	return got
+}
+
+// expectNoEvents fails the test when an event is received within a few
+// polls.
+func expectNoEvents(t *testing.T, events <-chan *kepserverex.Event) {
+	select {
+	case e := <-events:
+		t.Fatalf("received unexpected event %q", e.Message)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func expectEvents(t *testing.T, got []string, want ...string) {
+	if len(got) != len(want) {
+		t.Fatalf("received events %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("received events %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFollowEvents(t *testing.T) {
+	s := kepserverextest.NewServer("user", "secret")
+	defer s.Close()
+
+	c, err := s.NewClient()
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	since := time.Now().UTC().Truncate(time.Millisecond)
+	at := func(ms int) kepserverex.Timestamp {
+		return kepserverex.Timestamp{Time: since.Add(time.Duration(ms) * time.Millisecond)}
+	}
+
+	s.AddEvent(kepserverex.Event{Timestamp: at(-1), Message: "before"})
+	s.AddEvent(kepserverex.Event{Timestamp: at(0), Message: "e1"})
+	s.AddEvent(kepserverex.Event{Timestamp: at(1), Message: "e2"})
+	s.AddEvent(kepserverex.Event{Timestamp: at(2), Message: "e3"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, errs := c.EventLog.FollowEvents(ctx, &kepserverex.FollowEventsOptions{
+		Interval: 10 * time.Millisecond,
+		Since:    since,
+		Limit:    2,
+	})
+
+	// The events exceed the limit, so they are retrieved in two polls.
+	expectEvents(t, receiveEvents(t, events, 3), "e1", "e2", "e3")
+	expectNoEvents(t, events)
+
+	// New events are picked up by the next poll, including events logged
+	// at the same time as the last delivered event.
+	s.AddEvent(kepserverex.Event{Timestamp: at(2), Message: "e4"})
+	s.AddEvent(kepserverex.Event{Timestamp: at(3), Message: "e5"})
+
+	expectEvents(t, receiveEvents(t, events, 2), "e4", "e5")
+	expectNoEvents(t, events)
+
+	cancel()
+	for range events {
+	}
+	if err, ok := <-errs; ok {
+		t.Errorf("received unexpected error %v", err)
+	}
+}
+
+func TestFollowEventsBeyondLimit(t *testing.T) {
+	s := kepserverextest.NewServer("user", "secret")
+	defer s.Close()
+
+	c, err := s.NewClient()
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	since := time.Now().UTC().Truncate(time.Millisecond)
+	next := kepserverex.Timestamp{Time: since.Add(time.Millisecond)}
+
+	s.AddEvent(kepserverex.Event{Timestamp: kepserverex.Timestamp{Time: since}, Message: "e1"})
+	s.AddEvent(kepserverex.Event{Timestamp: kepserverex.Timestamp{Time: since}, Message: "e2"})
+	s.AddEvent(kepserverex.Event{Timestamp: kepserverex.Timestamp{Time: since}, Message: "e3"})
+	s.AddEvent(kepserverex.Event{Timestamp: next, Message: "e4"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, _ := c.EventLog.FollowEvents(ctx, &kepserverex.FollowEventsOptions{
+		Interval: 10 * time.Millisecond,
+		Since:    since,
+		Limit:    2,
+	})
+
+	// The third event in the same millisecond is beyond the limit, so it
+	// is skipped as documented.
+	expectEvents(t, receiveEvents(t, events, 3), "e1", "e2", "e4")
+	expectNoEvents(t, events)
+}
+
+func TestFollowEventsErrors(t *testing.T) {
+	var mu sync.Mutex
+	polls := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/config/v1/event_log", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		polls++
+		poll := polls
+		mu.Unlock()
+
+		if poll <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		io.WriteString(w, `[{"timestamp":"2019-06-01T12:00:00.000","event":"Information","source":"Runtime","message":"e1"}]`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c, err := kepserverex.NewClient(nil, "", "user", "secret")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := c.SetBaseURL(server.URL); err != nil {
+		t.Fatalf("Failed to set base URL: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, errs := c.EventLog.FollowEvents(ctx, &kepserverex.FollowEventsOptions{
+		Interval: 10 * time.Millisecond,
+		Since:    time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	// The first error is reported, while polling continues until the
+	// event is delivered.
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Fatal("received a nil error")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("received no error")
+	}
+	expectEvents(t, receiveEvents(t, events, 1), "e1")
+	expectNoEvents(t, events)
+
+	cancel()
+	for range events {
+	}
+	for range errs {
+	}
+}
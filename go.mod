@@ -2,4 +2,7 @@ module github.com/svanharmelen/go-kepserverex
 
 go 1.13
 
-require github.com/google/go-querystring v1.0.0
+require (
+	github.com/google/go-querystring v1.0.0
+	gopkg.in/yaml.v2 v2.4.0
+)
@@ -19,18 +19,22 @@ package kepserverex
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/google/go-querystring/query"
 )
 
 const (
-	apiVersionPath = "config/v1/project/"
+	apiRootPath    = "config/v1/"
+	apiVersionPath = apiRootPath + "project/"
 	userAgent      = "go-kepserverex"
 )
 
@@ -45,38 +49,35 @@ type Client struct {
 	// Username and password used for authentication.
 	username, password string
 
+	// User agent sent with every request.
+	userAgent string
+
+	// Maximum number of objects created with a single request.
+	bulkChunkSize int
+
+	// Policy used to retry failed requests, nil to disable retries.
+	retry *RetryPolicy
+
+	// Limiter used to limit requests, nil to disable limiting.
+	limiter *Limiter
+
+	// Settings of the client options, only used while creating the client.
+	config *clientConfig
+
 	// Services used for talking to different parts of the KEPServerEX API.
-	Channels  *ChannelService
-	Devices   *DeviceService
-	TagGroups *TagGroupService
-	Tags      *TagService
+	Channels       *ChannelService
+	Devices        *DeviceService
+	EventLog       *EventLogService
+	TagGroups      *TagGroupService
+	Tags           *TagService
+	TransactionLog *TransactionLogService
 }
 
 // NewClient returns a new KEPServerEX API client. If a nil httpClient is
 // provided, http.DefaultClient will be used. To use API methods which require
 // authentication, provide a valid username and password.
 func NewClient(httpClient *http.Client, host, username, password string) (*Client, error) {
-	if httpClient == nil {
-		httpClient = http.DefaultClient
-	}
-
-	c := &Client{
-		client:   httpClient,
-		username: username,
-		password: password,
-	}
-
-	if err := c.SetBaseURL("https://" + host); err != nil {
-		return nil, err
-	}
-
-	// Create all the public services.
-	c.Channels = &ChannelService{client: c}
-	c.Devices = &DeviceService{client: c}
-	c.TagGroups = &TagGroupService{client: c}
-	c.Tags = &TagService{client: c}
-
-	return c, nil
+	return NewClientWithOptions(host, username, password, WithHTTPClient(httpClient))
 }
 
 // BaseURL return a copy of the baseURL.
@@ -107,12 +108,34 @@ func (c *Client) SetBaseURL(urlStr string) error {
 	return nil
 }
 
+// SetBulkChunkSize sets the maximum number of objects created with a single
+// request when creating objects in bulk. Larger inputs are split into chunks
+// of at most this size. Values smaller than 1 reset it to the default (1000).
+func (c *Client) SetBulkChunkSize(size int) {
+	if size < 1 {
+		size = defaultBulkChunkSize
+	}
+	c.bulkChunkSize = size
+}
+
 // NewRequest creates an API request. A relative URL path can be provided in
 // path, in which case it is resolved relative to the base URL of the Client.
 // Relative URL paths should always be specified without a preceding slash. If
 // specified, the value pointed to by opt is JSON encoded and included as the
-// request body.
-func (c *Client) NewRequest(method, path string, opt interface{}) (*http.Request, error) {
+// request body. Any given request options are applied to the request before
+// it is returned.
+func (c *Client) NewRequest(method, path string, opt interface{}, options ...RequestOptionFunc) (*http.Request, error) {
+	return c.newRequest(method, c.baseURL.Path, path, opt, options)
+}
+
+// newRootRequest creates an API request for a path relative to the root of
+// the Configuration API (e.g. event_log), instead of relative to the project.
+func (c *Client) newRootRequest(method, path string, opt interface{}, options ...RequestOptionFunc) (*http.Request, error) {
+	basePath := strings.TrimSuffix(c.baseURL.Path, apiVersionPath) + apiRootPath
+	return c.newRequest(method, basePath, path, opt, options)
+}
+
+func (c *Client) newRequest(method, basePath, path string, opt interface{}, options []RequestOptionFunc) (*http.Request, error) {
 	u := *c.baseURL
 	unescaped, err := url.PathUnescape(path)
 	if err != nil {
@@ -120,8 +143,8 @@ func (c *Client) NewRequest(method, path string, opt interface{}) (*http.Request
 	}
 
 	// Set the encoded path data
-	u.RawPath = c.baseURL.Path + path
-	u.Path = c.baseURL.Path + unescaped
+	u.RawPath = basePath + path
+	u.Path = basePath + unescaped
 
 	req := &http.Request{
 		Method:     method,
@@ -134,7 +157,7 @@ func (c *Client) NewRequest(method, path string, opt interface{}) (*http.Request
 	}
 
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("User-Agent", c.userAgent)
 	req.SetBasicAuth(c.username, c.password)
 
 	switch {
@@ -152,14 +175,24 @@ func (c *Client) NewRequest(method, path string, opt interface{}) (*http.Request
 		if err = encoder.Encode(opt); err != nil {
 			return nil, err
 		}
-		req.Body = ioutil.NopCloser(body)
+		data := body.Bytes()
+		req.Body = ioutil.NopCloser(bytes.NewReader(data))
 		req.GetBody = func() (io.ReadCloser, error) {
-			return ioutil.NopCloser(body), nil
+			return ioutil.NopCloser(bytes.NewReader(data)), nil
 		}
-		req.ContentLength = int64(body.Len())
+		req.ContentLength = int64(len(data))
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	for _, fn := range options {
+		if fn == nil {
+			continue
+		}
+		if err := fn(req); err != nil {
+			return nil, err
+		}
+	}
+
 	return req, nil
 }
 
@@ -167,17 +200,31 @@ func (c *Client) NewRequest(method, path string, opt interface{}) (*http.Request
 // JSON decoded and stored in the value pointed to by v, or returned as an
 // error if an API error has occurred. If v implements the io.Writer
 // interface, the raw response body will be written to v, without attempting to
-// first decode it.
+// first decode it. If the client has a retry policy, requests failing with a
+// transient error are retried according to it. The request is canceled when
+// the context of the request is canceled or its deadline is exceeded, in
+// which case the context's error is returned.
 func (c *Client) Do(req *http.Request, v interface{}) error {
-	resp, err := c.client.Do(req)
+	_, err := c.do(req, v)
+	return err
+}
+
+// do sends an API request like Do, but also returns the API response.
+func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
+	resp, err := c.send(req)
 	if err != nil {
-		return err
+		// If we got an error and the context has been canceled,
+		// the context's error is probably more useful.
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	err = CheckResponse(resp)
 	if err != nil {
-		return err
+		return resp, err
 	}
 
 	if v != nil {
@@ -188,39 +235,192 @@ func (c *Client) Do(req *http.Request, v interface{}) error {
 		}
 	}
 
-	return err
+	return resp, err
+}
+
+// ErrProjectChanged is returned when KEPServerEX rejects a change because the
+// PROJECT_ID sent with the request no longer matches the current project,
+// meaning the project was changed after the object was read.
+var ErrProjectChanged = errors.New("kepserverex: project has changed since it was read")
+
+// Errors which can be used with errors.Is to check the kind of error returned
+// by an API request.
+var (
+	ErrNotFound        = errors.New("kepserverex: object not found")
+	ErrAlreadyExists   = errors.New("kepserverex: object already exists")
+	ErrUnauthorized    = errors.New("kepserverex: unauthorized")
+	ErrForbidden       = errors.New("kepserverex: forbidden")
+	ErrConflict        = errors.New("kepserverex: conflict")
+	ErrInvalidProperty = errors.New("kepserverex: invalid property")
+)
+
+// A PropertyError reports a property that failed validation.
+type PropertyError struct {
+	Property    string `json:"property"`
+	Description string `json:"description"`
+	Line        int    `json:"error_line,omitempty"`
+}
+
+func (e *PropertyError) Error() string {
+	return fmt.Sprintf("property %s: %s", e.Property, e.Description)
 }
 
 // An ErrorResponse reports errors caused by an API request.
 type ErrorResponse struct {
-	Code     int
-	Response *http.Response
-	Message  string
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+
+	// Properties that failed validation, if any.
+	Properties []*PropertyError `json:"-"`
+
+	// Body contains the raw response body, for diagnostics.
+	Body []byte `json:"-"`
+
+	Response *http.Response `json:"-"`
 }
 
 func (e *ErrorResponse) Error() string {
 	path, _ := url.QueryUnescape(e.Response.Request.URL.Path)
 	u := fmt.Sprintf("%s://%s%s", e.Response.Request.URL.Scheme, e.Response.Request.URL.Host, path)
-	return fmt.Sprintf("%s %s: %d %s", e.Response.Request.Method, u, e.Response.StatusCode, e.Message)
+	return fmt.Sprintf("%s %s: %d %s", e.Response.Request.Method, u, e.Code, e.Message)
+}
+
+// Is reports whether the error matches the target error, so the error
+// can be checked using errors.Is.
+func (e *ErrorResponse) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Code == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.Code == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.Code == http.StatusForbidden
+	case ErrConflict:
+		return e.Code == http.StatusConflict || e.Is(ErrProjectChanged)
+	case ErrAlreadyExists:
+		for _, p := range e.Properties {
+			if p.Property == nameProperty && strings.Contains(p.Description, "already") {
+				return true
+			}
+		}
+		return duplicateName.MatchString(e.Message)
+	case ErrInvalidProperty:
+		return e.Code == http.StatusBadRequest && len(e.Properties) > 0
+	case ErrProjectChanged:
+		if e.Code != http.StatusBadRequest {
+			return false
+		}
+		for _, p := range e.Properties {
+			if p.Property == "PROJECT_ID" {
+				return true
+			}
+		}
+		return strings.Contains(e.Message, "PROJECT_ID")
+	}
+	return false
+}
+
+// validationMessage matches the message KEPServerEX returns when a property
+// fails validation.
+var validationMessage = regexp.MustCompile(
+	`^Validation failed on property (\S+) in object definition(?: at line (\d+))?: (.*)$`)
+
+// duplicateName matches the message KEPServerEX returns when an object with
+// the same name already exists.
+var duplicateName = regexp.MustCompile(`(?i)name '[^']*' is already (used|in use)|already exists`)
+
+// apiError represents a single error as returned by KEPServerEX.
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	PropertyError
+}
+
+// parseErrors parses the errors in the given response body. KEPServerEX
+// returns either a single error, or a list of errors when multiple properties
+// failed validation or when multiple objects were created at once.
+func parseErrors(data []byte) []*apiError {
+	var errs []*apiError
+	if err := json.Unmarshal(data, &errs); err != nil {
+		e := new(apiError)
+		if err := json.Unmarshal(data, e); err != nil {
+			return nil
+		}
+		errs = []*apiError{e}
+	}
+
+	for _, e := range errs {
+		if e.Property == "" {
+			if m := validationMessage.FindStringSubmatch(e.Message); m != nil {
+				e.Property, e.Description = m[1], m[3]
+				e.Line, _ = strconv.Atoi(m[2])
+			}
+		}
+	}
+
+	return errs
+}
+
+// properties returns the property that failed validation, if any.
+func (e *apiError) properties() []*PropertyError {
+	if e.Property == "" {
+		return nil
+	}
+	pe := e.PropertyError
+	return []*PropertyError{&pe}
 }
 
 // CheckResponse checks the API response for errors, and returns them if present.
 func CheckResponse(r *http.Response) error {
 	switch r.StatusCode {
-	case 200, 201, 202, 204:
+	case 200, 201, 202, 204, 207:
 		return nil
 	}
 
-	errorResponse := &ErrorResponse{Response: r}
-	err := json.NewDecoder(r.Body).Decode(errorResponse)
-	if err != nil {
-		errorResponse.Code = 500
-		errorResponse.Message = "failed to parse unknown error format"
+	errorResponse := &ErrorResponse{Code: r.StatusCode, Response: r}
+	data, err := ioutil.ReadAll(r.Body)
+	if err == nil {
+		errorResponse.Body = data
+	}
+
+	errs := parseErrors(data)
+	for _, e := range errs {
+		errorResponse.Properties = append(errorResponse.Properties, e.properties()...)
+	}
+
+	switch {
+	case len(errs) > 0:
+		if errs[0].Code != 0 {
+			errorResponse.Code = errs[0].Code
+		}
+		messages := make([]string, 0, len(errs))
+		for _, e := range errs {
+			messages = append(messages, e.Message)
+		}
+		errorResponse.Message = strings.Join(messages, "; ")
+	case len(bytes.TrimSpace(data)) > 0:
+		errorResponse.Message = string(bytes.TrimSpace(data))
+	default:
+		errorResponse.Message = http.StatusText(r.StatusCode)
 	}
 
 	return errorResponse
 }
 
+// RetryOnProjectChange calls fn until it succeeds or returns an error other
+// than ErrProjectChanged, for at most the given number of attempts. As the
+// project changed in between, fn should (re-)read the object it changes and
+// pass the newly read PROJECT_ID along with the update on every call.
+func RetryOnProjectChange(attempts int, fn func() error) error {
+	var err error
+	for i := 0; i < attempts; i++ {
+		if err = fn(); !errors.Is(err, ErrProjectChanged) {
+			return err
+		}
+	}
+	return err
+}
+
 // Bool is a helper routine that allocates a new bool value
 // to store v and returns a pointer to it.
 func Bool(v bool) *bool {
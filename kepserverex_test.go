@@ -0,0 +1,357 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setup sets up a test HTTP server along with a Client that is configured
+// to talk to that test server. Tests should register handlers on the mux
+// which provide mock responses for the API method being tested.
+func setup(t *testing.T) (*http.ServeMux, *httptest.Server, *Client) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client, err := NewClient(nil, "", "user", "secret")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := client.SetBaseURL(server.URL); err != nil {
+		t.Fatalf("Failed to set base URL: %v", err)
+	}
+
+	return mux, server, client
+}
+
+// teardown closes the test HTTP server.
+func teardown(server *httptest.Server) {
+	server.Close()
+}
+
+func TestNewRequestWithoutOptions(t *testing.T) {
+	_, server, client := setup(t)
+	defer teardown(server)
+
+	req, err := client.NewRequest("GET", "channels", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if want := "/config/v1/project/channels"; req.URL.Path != want {
+		t.Errorf("NewRequest path is %q, want %q", req.URL.Path, want)
+	}
+	if req.Context() != context.Background() {
+		t.Errorf("NewRequest context is not the background context")
+	}
+}
+
+func TestDoCanceledContext(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	mux.HandleFunc("/config/v1/project/channels", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := client.Channels.ListChannels(WithContext(ctx))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ListChannels returned error %v, want %v", err, context.Canceled)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("ListChannels returned after %v, want it to be aborted right away", elapsed)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []apiError
+	}{
+		{
+			name: "validation error with property",
+			body: `{"code":400,"message":"Validation failed on property common.ALLTYPES_NAME in object definition at line 3: The name 'Channel1' is already used.","property":"common.ALLTYPES_NAME","description":"The name 'Channel1' is already used.","error_line":3}`,
+			want: []apiError{{
+				Code:    400,
+				Message: "Validation failed on property common.ALLTYPES_NAME in object definition at line 3: The name 'Channel1' is already used.",
+				PropertyError: PropertyError{
+					Property:    "common.ALLTYPES_NAME",
+					Description: "The name 'Channel1' is already used.",
+					Line:        3,
+				},
+			}},
+		},
+		{
+			name: "validation error parsed from message",
+			body: `{"code":400,"message":"Validation failed on property servermain.CHANNEL_SERIAL_COMMUNICATIONS_BAUD_RATE in object definition at line 7: Value is out of range."}`,
+			want: []apiError{{
+				Code:    400,
+				Message: "Validation failed on property servermain.CHANNEL_SERIAL_COMMUNICATIONS_BAUD_RATE in object definition at line 7: Value is out of range.",
+				PropertyError: PropertyError{
+					Property:    "servermain.CHANNEL_SERIAL_COMMUNICATIONS_BAUD_RATE",
+					Description: "Value is out of range.",
+					Line:        7,
+				},
+			}},
+		},
+		{
+			name: "validation error without line",
+			body: `{"code":400,"message":"Validation failed on property PROJECT_ID in object definition: The project has been modified since it was last read."}`,
+			want: []apiError{{
+				Code:    400,
+				Message: "Validation failed on property PROJECT_ID in object definition: The project has been modified since it was last read.",
+				PropertyError: PropertyError{
+					Property:    "PROJECT_ID",
+					Description: "The project has been modified since it was last read.",
+				},
+			}},
+		},
+		{
+			name: "multiple validation errors",
+			body: `[{"code":400,"message":"Validation failed on property servermain.TAG_ADDRESS in object definition at line 4: Invalid address."},{"code":400,"message":"Validation failed on property servermain.TAG_SCAN_RATE_MILLISECONDS in object definition at line 6: Value is out of range."}]`,
+			want: []apiError{
+				{
+					Code:    400,
+					Message: "Validation failed on property servermain.TAG_ADDRESS in object definition at line 4: Invalid address.",
+					PropertyError: PropertyError{
+						Property:    "servermain.TAG_ADDRESS",
+						Description: "Invalid address.",
+						Line:        4,
+					},
+				},
+				{
+					Code:    400,
+					Message: "Validation failed on property servermain.TAG_SCAN_RATE_MILLISECONDS in object definition at line 6: Value is out of range.",
+					PropertyError: PropertyError{
+						Property:    "servermain.TAG_SCAN_RATE_MILLISECONDS",
+						Description: "Value is out of range.",
+						Line:        6,
+					},
+				},
+			},
+		},
+		{
+			name: "error without property",
+			body: `{"code":404,"message":"The requested resource 'channels/Channel2' was not found."}`,
+			want: []apiError{{Code: 404, Message: "The requested resource 'channels/Channel2' was not found."}},
+		},
+		{
+			name: "not JSON",
+			body: `<html><body><h1>502 Bad Gateway</h1></body></html>`,
+		},
+		{
+			name: "empty body",
+			body: ``,
+		},
+	}
+
+	for _, tt := range tests {
+		got := parseErrors([]byte(tt.body))
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: parseErrors returned %d errors, want %d", tt.name, len(got), len(tt.want))
+			continue
+		}
+		for i := range got {
+			if *got[i] != tt.want[i] {
+				t.Errorf("%s: parseErrors returned %+v, want %+v", tt.name, *got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestCheckResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantCode    int
+		wantMessage string
+		wantProps   []string
+		is          []error
+		isNot       []error
+	}{
+		{
+			name:        "duplicate name",
+			status:      400,
+			body:        `{"code":400,"message":"Validation failed on property common.ALLTYPES_NAME in object definition at line 3: The name 'Channel1' is already used.","property":"common.ALLTYPES_NAME","description":"The name 'Channel1' is already used.","error_line":3}`,
+			wantCode:    400,
+			wantMessage: "Validation failed on property common.ALLTYPES_NAME in object definition at line 3: The name 'Channel1' is already used.",
+			wantProps:   []string{"common.ALLTYPES_NAME"},
+			is:          []error{ErrAlreadyExists, ErrInvalidProperty},
+			isNot:       []error{ErrConflict, ErrProjectChanged, ErrNotFound},
+		},
+		{
+			name:        "project changed",
+			status:      400,
+			body:        `{"code":400,"message":"Validation failed on property PROJECT_ID in object definition at line 2: The project has been modified since it was last read."}`,
+			wantCode:    400,
+			wantMessage: "Validation failed on property PROJECT_ID in object definition at line 2: The project has been modified since it was last read.",
+			wantProps:   []string{"PROJECT_ID"},
+			is:          []error{ErrProjectChanged, ErrConflict, ErrInvalidProperty},
+			isNot:       []error{ErrAlreadyExists},
+		},
+		{
+			name:        "multiple invalid properties",
+			status:      400,
+			body:        `[{"code":400,"message":"Validation failed on property servermain.TAG_ADDRESS in object definition at line 4: Invalid address."},{"code":400,"message":"Validation failed on property servermain.TAG_SCAN_RATE_MILLISECONDS in object definition at line 6: Value is out of range."}]`,
+			wantCode:    400,
+			wantMessage: "Validation failed on property servermain.TAG_ADDRESS in object definition at line 4: Invalid address.; Validation failed on property servermain.TAG_SCAN_RATE_MILLISECONDS in object definition at line 6: Value is out of range.",
+			wantProps:   []string{"servermain.TAG_ADDRESS", "servermain.TAG_SCAN_RATE_MILLISECONDS"},
+			is:          []error{ErrInvalidProperty},
+			isNot:       []error{ErrAlreadyExists, ErrProjectChanged},
+		},
+		{
+			name:        "not found",
+			status:      404,
+			body:        `{"code":404,"message":"The requested resource 'channels/Channel2' was not found."}`,
+			wantCode:    404,
+			wantMessage: "The requested resource 'channels/Channel2' was not found.",
+			is:          []error{ErrNotFound},
+			isNot:       []error{ErrInvalidProperty, ErrConflict},
+		},
+		{
+			name:        "unauthorized without body",
+			status:      401,
+			wantCode:    401,
+			wantMessage: "Unauthorized",
+			is:          []error{ErrUnauthorized},
+			isNot:       []error{ErrForbidden},
+		},
+		{
+			name:        "forbidden",
+			status:      403,
+			body:        `{"code":403,"message":"User 'operator' does not have permission to modify the project."}`,
+			wantCode:    403,
+			wantMessage: "User 'operator' does not have permission to modify the project.",
+			is:          []error{ErrForbidden},
+			isNot:       []error{ErrUnauthorized},
+		},
+		{
+			name:        "bare conflict",
+			status:      409,
+			body:        `{"code":409,"message":"The project is locked by another client."}`,
+			wantCode:    409,
+			wantMessage: "The project is locked by another client.",
+			is:          []error{ErrConflict},
+			isNot:       []error{ErrAlreadyExists, ErrProjectChanged},
+		},
+		{
+			name:        "proxy error page",
+			status:      502,
+			body:        "<html><body><h1>502 Bad Gateway</h1></body></html>\n",
+			wantCode:    502,
+			wantMessage: "<html><body><h1>502 Bad Gateway</h1></body></html>",
+			isNot:       []error{ErrNotFound, ErrConflict},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, server, client := setup(t)
+			defer teardown(server)
+
+			mux.HandleFunc("/config/v1/project/channels", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			})
+
+			_, err := client.Channels.ListChannels()
+
+			var errResp *ErrorResponse
+			if !errors.As(err, &errResp) {
+				t.Fatalf("ListChannels returned error %v, want an *ErrorResponse", err)
+			}
+			if errResp.Code != tt.wantCode {
+				t.Errorf("Code is %d, want %d", errResp.Code, tt.wantCode)
+			}
+			if errResp.Message != tt.wantMessage {
+				t.Errorf("Message is %q, want %q", errResp.Message, tt.wantMessage)
+			}
+			if string(errResp.Body) != tt.body {
+				t.Errorf("Body is %q, want %q", errResp.Body, tt.body)
+			}
+
+			var props []string
+			for _, p := range errResp.Properties {
+				props = append(props, p.Property)
+			}
+			if strings.Join(props, ",") != strings.Join(tt.wantProps, ",") {
+				t.Errorf("Properties are %v, want %v", props, tt.wantProps)
+			}
+
+			for _, target := range tt.is {
+				if !errors.Is(err, target) {
+					t.Errorf("errors.Is(err, %v) is false, want true", target)
+				}
+			}
+			for _, target := range tt.isNot {
+				if errors.Is(err, target) {
+					t.Errorf("errors.Is(err, %v) is true, want false", target)
+				}
+			}
+		})
+	}
+}
+
+func TestValidationMessage(t *testing.T) {
+	tests := []struct {
+		message  string
+		property string
+		line     string
+		desc     string
+	}{
+		{
+			"Validation failed on property common.ALLTYPES_NAME in object definition at line 3: The name 'A' is already used.",
+			"common.ALLTYPES_NAME", "3", "The name 'A' is already used.",
+		},
+		{
+			"Validation failed on property PROJECT_ID in object definition: Project ID mismatch.",
+			"PROJECT_ID", "", "Project ID mismatch.",
+		},
+		{"The requested resource was not found.", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		m := validationMessage.FindStringSubmatch(tt.message)
+		if tt.property == "" {
+			if m != nil {
+				t.Errorf("validationMessage matched %q", tt.message)
+			}
+			continue
+		}
+		if m == nil || m[1] != tt.property || m[2] != tt.line || m[3] != tt.desc {
+			t.Errorf("validationMessage matched %q as %q", tt.message, m)
+		}
+	}
+}
@@ -0,0 +1,703 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package kepserverextest provides an in-process fake of the KEPServerEX
+// Configuration API that can be used to test code using the kepserverex
+// package without having a running KEPServerEX instance.
+package kepserverextest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	kepserverex "github.com/svanharmelen/go-kepserverex"
+)
+
+const (
+	apiRootPath    = "/config/v1"
+	apiVersionPath = apiRootPath + "/project"
+
+	nameProperty      = "common.ALLTYPES_NAME"
+	driverProperty    = "servermain.MULTIPLE_TYPES_DEVICE_DRIVER"
+	projectIDProperty = "PROJECT_ID"
+)
+
+// kind represents the type of an object in the project tree.
+type kind int
+
+const (
+	projectKind kind = iota
+	channelKind
+	deviceKind
+	tagGroupKind
+	tagKind
+)
+
+// collections maps the collections an object of a given kind can contain
+// to the kind of the objects in that collection.
+var collections = map[kind]map[string]kind{
+	projectKind:  {"channels": channelKind},
+	channelKind:  {"devices": deviceKind},
+	deviceKind:   {"tag_groups": tagGroupKind, "tags": tagKind},
+	tagGroupKind: {"tag_groups": tagGroupKind, "tags": tagKind},
+}
+
+// uniqueIDProperties maps object kinds to their unique ID property.
+var uniqueIDProperties = map[kind]string{
+	channelKind: "servermain.CHANNEL_UNIQUE_ID",
+	deviceKind:  "servermain.DEVICE_UNIQUE_ID",
+}
+
+// object represents a single object in the project tree.
+type object struct {
+	kind     kind
+	parent   *object
+	props    map[string]interface{}
+	children map[string][]*object
+
+	// Runtime state of channels and devices.
+	stats  kepserverex.Statistics
+	status *kepserverex.DeviceStatus
+}
+
+func newObject(k kind, props map[string]interface{}) *object {
+	if props == nil {
+		props = make(map[string]interface{})
+	}
+	return &object{
+		kind:     k,
+		props:    props,
+		children: make(map[string][]*object),
+	}
+}
+
+func (o *object) name() string {
+	name, _ := o.props[nameProperty].(string)
+	return name
+}
+
+// path returns the dotted path of names leading to the object, as used
+// in the transaction log (e.g. Channel1.Device1.Tag1).
+func (o *object) path() string {
+	if o.parent == nil || o.parent.kind == projectKind {
+		return o.name()
+	}
+	return o.parent.path() + "." + o.name()
+}
+
+func (o *object) child(collection, name string) *object {
+	for _, c := range o.children[collection] {
+		if strings.EqualFold(c.name(), name) {
+			return c
+		}
+	}
+	return nil
+}
+
+func (o *object) removeChild(collection string, child *object) {
+	items := o.children[collection]
+	for i, c := range items {
+		if c == child {
+			o.children[collection] = append(items[:i:i], items[i+1:]...)
+			return
+		}
+	}
+}
+
+// Server is an in-process fake KEPServerEX Configuration API server. It
+// keeps the project tree (channels, devices, tag groups and tags) in memory
+// and bumps the PROJECT_ID on every change, just like KEPServerEX does.
+type Server struct {
+	*httptest.Server
+
+	// Username and password required to authenticate against the server.
+	Username, Password string
+
+	mu           sync.Mutex
+	projectID    int64
+	nextUniqueID int64
+	project      *object
+	events       []*kepserverex.Event
+	transactions []*kepserverex.Transaction
+}
+
+// NewServer starts and returns a new fake KEPServerEX server requiring
+// the given username and password. The caller should call Close when
+// finished, to shut it down.
+func NewServer(username, password string) *Server {
+	s := &Server{
+		Username:     username,
+		Password:     password,
+		projectID:    1,
+		nextUniqueID: 1,
+		project:      newObject(projectKind, nil),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// NewClient returns a new kepserverex.Client configured to talk to the
+// fake server using the server's credentials.
+func (s *Server) NewClient() (*kepserverex.Client, error) {
+	c, err := kepserverex.NewClient(s.Server.Client(), s.Listener.Addr().String(), s.Username, s.Password)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.SetBaseURL(s.URL); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ProjectID returns the current PROJECT_ID of the fake project.
+func (s *Server) ProjectID() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.projectID
+}
+
+// Reset removes all objects from the fake project.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.project = newObject(projectKind, nil)
+	s.projectID++
+}
+
+// SetStatistics sets the runtime statistics returned for the given channel,
+// or for the given device of the channel when device is not empty.
+func (s *Server) SetStatistics(channel, device string, stats kepserverex.Statistics) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, err := s.runtimeObject(channel, device)
+	if err != nil {
+		return err
+	}
+	obj.stats = stats
+
+	return nil
+}
+
+// SetDeviceStatus sets the runtime status returned for the given device.
+// By default the status reflects the device's configuration.
+func (s *Server) SetDeviceStatus(channel, device string, status kepserverex.DeviceStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, err := s.runtimeObject(channel, device)
+	if err != nil {
+		return err
+	}
+	obj.status = &status
+
+	return nil
+}
+
+// AddEvent adds an entry to the fake event log. A zero timestamp is set
+// to the current time.
+func (s *Server) AddEvent(e kepserverex.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e.Timestamp.IsZero() {
+		e.Timestamp.Time = time.Now()
+	}
+	e.Timestamp.Time = e.Timestamp.UTC().Truncate(time.Millisecond)
+	s.events = append(s.events, &e)
+}
+
+// AddTransaction adds an entry to the fake transaction log. A zero timestamp
+// is set to the current time. Changes made through the API are added to the
+// transaction log automatically.
+func (s *Server) AddTransaction(t kepserverex.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addTransaction(t)
+}
+
+func (s *Server) addTransaction(t kepserverex.Transaction) {
+	if t.Timestamp.IsZero() {
+		t.Timestamp.Time = time.Now()
+	}
+	t.Timestamp.Time = t.Timestamp.UTC().Truncate(time.Millisecond)
+	s.transactions = append(s.transactions, &t)
+}
+
+// logChange adds a transaction log entry for a change made to the given
+// object by the authenticated user of the request. The changed properties,
+// if any, are included as the entry's data.
+func (s *Server) logChange(r *http.Request, action string, obj *object, props map[string]interface{}) {
+	username, _, _ := r.BasicAuth()
+	t := kepserverex.Transaction{
+		User:   username,
+		Source: "Config API REST Service",
+		Action: action,
+		Object: obj.path(),
+	}
+	if props != nil {
+		data, _ := json.Marshal(props)
+		t.Data = string(data)
+	}
+	s.addTransaction(t)
+}
+
+func (s *Server) runtimeObject(channel, device string) (*object, error) {
+	obj := s.project.child("channels", channel)
+	if obj == nil {
+		return nil, fmt.Errorf("channel %q not found", channel)
+	}
+	if device == "" {
+		return obj, nil
+	}
+	if obj = obj.child("devices", device); obj == nil {
+		return nil, fmt.Errorf("device %q not found in channel %q", device, channel)
+	}
+	return obj, nil
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	username, password, ok := r.BasicAuth()
+	if !ok || username != s.Username || password != s.Password {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.EscapedPath(), "/")
+	if path == apiRootPath+"/event_log" || path == apiRootPath+"/transaction_log" {
+		s.listLog(w, r, strings.TrimPrefix(path, apiRootPath+"/"))
+		return
+	}
+	if path != apiVersionPath && !strings.HasPrefix(path, apiVersionPath+"/") {
+		writeError(w, http.StatusNotFound, "The requested resource was not found")
+		return
+	}
+
+	var segments []string
+	for _, seg := range strings.Split(strings.TrimPrefix(path, apiVersionPath), "/")[1:] {
+		unescaped, err := url.PathUnescape(seg)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid path segment '%s'", seg))
+			return
+		}
+		segments = append(segments, unescaped)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Walk the project tree to find the addressed object or collection.
+	parent, obj, collection := (*object)(nil), s.project, ""
+	for i := 0; i < len(segments); i += 2 {
+		if i+1 == len(segments) && r.Method == "GET" && s.handleRuntime(w, obj, segments[i]) {
+			return
+		}
+		if _, ok := collections[obj.kind][segments[i]]; !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("The requested resource '%s' was not found", segments[i]))
+			return
+		}
+		if i+1 == len(segments) {
+			parent, obj, collection = obj, nil, segments[i]
+			break
+		}
+		child := obj.child(segments[i], segments[i+1])
+		if child == nil {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("The object '%s' was not found", segments[i+1]))
+			return
+		}
+		parent, obj, collection = obj, child, segments[i]
+	}
+
+	switch {
+	case obj == nil && r.Method == "GET":
+		s.listObjects(w, r, parent, collection)
+	case obj == nil && r.Method == "POST":
+		s.createObject(w, r, parent, collection)
+	case obj != nil && r.Method == "GET" && r.URL.Query().Get("content") == "serialize":
+		writeJSON(w, http.StatusOK, s.serialize(obj))
+	case obj != nil && r.Method == "GET":
+		writeJSON(w, http.StatusOK, s.render(obj))
+	case obj != nil && obj.kind != projectKind && r.Method == "PUT":
+		s.updateObject(w, r, parent, collection, obj)
+	case obj != nil && obj.kind != projectKind && r.Method == "DELETE":
+		parent.removeChild(collection, obj)
+		s.projectID++
+		s.logChange(r, "Delete", obj, nil)
+		w.WriteHeader(http.StatusOK)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method %s is not allowed", r.Method))
+	}
+}
+
+// handleRuntime handles requests for the runtime statistics and status of
+// channels and devices. It returns false if the request is not a runtime
+// request.
+func (s *Server) handleRuntime(w http.ResponseWriter, obj *object, resource string) bool {
+	switch {
+	case resource == "_statistics" && (obj.kind == channelKind || obj.kind == deviceKind):
+		writeJSON(w, http.StatusOK, obj.stats)
+	case resource == "_system" && obj.kind == deviceKind:
+		status := obj.status
+		if status == nil {
+			simulated, _ := obj.props["servermain.DEVICE_SIMULATED"].(bool)
+			enabled, ok := obj.props["servermain.DEVICE_DATA_COLLECTION"].(bool)
+			status = &kepserverex.DeviceStatus{
+				Simulated:      simulated,
+				DataCollection: enabled || !ok,
+			}
+		}
+		writeJSON(w, http.StatusOK, status)
+	default:
+		return false
+	}
+	return true
+}
+
+// listLog handles requests for the entries of the event or transaction log.
+func (s *Server) listLog(w http.ResponseWriter, r *http.Request, log string) {
+	if r.Method != "GET" {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method %s is not allowed", r.Method))
+		return
+	}
+
+	start, end, limit, err := logFilters(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]interface{}, 0, limit)
+	add := func(ts kepserverex.Timestamp, entry interface{}) {
+		if ts.Before(start) || (!end.IsZero() && ts.After(end)) || len(entries) == limit {
+			return
+		}
+		entries = append(entries, entry)
+	}
+
+	switch log {
+	case "event_log":
+		for _, e := range s.events {
+			add(e.Timestamp, e)
+		}
+	case "transaction_log":
+		for _, t := range s.transactions {
+			add(t.Timestamp, t)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// logFilters parses the start, end and limit query parameters used by the
+// event and transaction logs.
+func logFilters(r *http.Request) (start, end time.Time, limit int, err error) {
+	q := r.URL.Query()
+
+	limit = 100
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 1 {
+			return start, end, 0, fmt.Errorf("Invalid limit '%s'", v)
+		}
+	}
+
+	var ts kepserverex.Timestamp
+	if v := q.Get("start"); v != "" {
+		if err = ts.UnmarshalJSON([]byte(v)); err != nil {
+			return start, end, 0, fmt.Errorf("Invalid start '%s'", v)
+		}
+		start = ts.Time
+	}
+	if v := q.Get("end"); v != "" {
+		if err = ts.UnmarshalJSON([]byte(v)); err != nil {
+			return start, end, 0, fmt.Errorf("Invalid end '%s'", v)
+		}
+		end = ts.Time
+	}
+
+	return start, end, limit, nil
+}
+
+func (s *Server) listObjects(w http.ResponseWriter, r *http.Request, parent *object, collection string) {
+	objects := make([]map[string]interface{}, 0, len(parent.children[collection]))
+	for _, obj := range parent.children[collection] {
+		objects = append(objects, s.render(obj))
+	}
+	writeJSON(w, http.StatusOK, objects)
+}
+
+func (s *Server) createObject(w http.ResponseWriter, r *http.Request, parent *object, collection string) {
+	objects, bulk, err := decodeObjects(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON in request body: "+err.Error())
+		return
+	}
+
+	if !bulk {
+		if res := s.create(r, parent, collection, objects[0]); res != nil {
+			writeJSON(w, res.Code, res)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	// When creating multiple objects at once, the result of each object is
+	// returned if any of them failed.
+	results := make([]*result, len(objects))
+	failed := false
+	for i, props := range objects {
+		if results[i] = s.create(r, parent, collection, props); results[i] != nil {
+			failed = true
+			continue
+		}
+		results[i] = &result{Code: http.StatusCreated, Message: "Created"}
+	}
+	if failed {
+		writeJSON(w, http.StatusMultiStatus, results)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// create creates a single object in the given collection, returning the
+// error result if the object is not valid.
+func (s *Server) create(r *http.Request, parent *object, collection string, props map[string]interface{}) *result {
+	obj := newObject(collections[parent.kind][collection], props)
+	obj.parent = parent
+	name, ok := props[nameProperty].(string)
+	if !ok || name == "" {
+		return validationError(nameProperty, "The property is required")
+	}
+	if parent.child(collection, name) != nil {
+		return validationError(nameProperty, fmt.Sprintf("The name '%s' is already used", name))
+	}
+
+	delete(props, projectIDProperty)
+	if prop, ok := uniqueIDProperties[obj.kind]; ok {
+		props[prop] = s.nextUniqueID
+		s.nextUniqueID++
+	}
+	if obj.kind == deviceKind {
+		props["servermain.DEVICE_CHANNEL_ASSIGNMENT"] = parent.name()
+		if _, ok := props[driverProperty]; !ok {
+			props[driverProperty] = parent.props[driverProperty]
+		}
+	}
+
+	parent.children[collection] = append(parent.children[collection], obj)
+	s.projectID++
+	s.logChange(r, "Add", obj, props)
+
+	return nil
+}
+
+func (s *Server) updateObject(w http.ResponseWriter, r *http.Request, parent *object, collection string, obj *object) {
+	props, err := decodeProperties(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON in request body: "+err.Error())
+		return
+	}
+
+	// A PROJECT_ID is optional, but when given it must match the current one.
+	if id, ok := props[projectIDProperty]; ok {
+		if n, ok := id.(json.Number); !ok || n.String() != strconv.FormatInt(s.projectID, 10) {
+			writeValidationError(w, projectIDProperty, fmt.Sprintf(
+				"The project has been modified since it was last read (current %s is %d)", projectIDProperty, s.projectID))
+			return
+		}
+	}
+
+	if name, ok := props[nameProperty].(string); ok && !strings.EqualFold(name, obj.name()) {
+		if name == "" {
+			writeValidationError(w, nameProperty, "The property is required")
+			return
+		}
+		if parent.child(collection, name) != nil {
+			writeValidationError(w, nameProperty, fmt.Sprintf("The name '%s' is already used", name))
+			return
+		}
+	}
+
+	// Read-only properties cannot be changed.
+	delete(props, projectIDProperty)
+	delete(props, uniqueIDProperties[obj.kind])
+	if obj.kind == deviceKind {
+		delete(props, "servermain.DEVICE_CHANNEL_ASSIGNMENT")
+	}
+
+	for k, v := range props {
+		obj.props[k] = v
+	}
+	s.projectID++
+	s.logChange(r, "Modify", obj, props)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// render returns the properties of the given object as they are returned
+// by KEPServerEX, so including the current PROJECT_ID.
+func (s *Server) render(obj *object) map[string]interface{} {
+	props := make(map[string]interface{}, len(obj.props)+3)
+	for k, v := range obj.props {
+		props[k] = v
+	}
+	props[projectIDProperty] = s.projectID
+
+	switch obj.kind {
+	case channelKind:
+		count := 0
+		for _, device := range obj.children["devices"] {
+			count += totalTagCount(device)
+		}
+		props["servermain.CHANNEL_STATIC_TAG_COUNT"] = count
+	case tagGroupKind:
+		props["servermain.TAGGROUP_LOCAL_TAG_COUNT"] = len(obj.children["tags"])
+		props["servermain.TAGGROUP_TOTAL_TAG_COUNT"] = totalTagCount(obj)
+	}
+
+	return props
+}
+
+// serialize returns the rendered object including all its (nested)
+// children, like KEPServerEX does when requesting content=serialize.
+func (s *Server) serialize(obj *object) map[string]interface{} {
+	props := s.render(obj)
+	for collection := range collections[obj.kind] {
+		children := make([]map[string]interface{}, 0, len(obj.children[collection]))
+		for _, child := range obj.children[collection] {
+			children = append(children, s.serialize(child))
+		}
+		props[collection] = children
+	}
+	return props
+}
+
+func totalTagCount(obj *object) int {
+	count := len(obj.children["tags"])
+	for _, group := range obj.children["tag_groups"] {
+		count += totalTagCount(group)
+	}
+	return count
+}
+
+// decodeObjects decodes the request body, which contains either a single
+// object or a list of objects. It reports whether a list was decoded.
+func decodeObjects(r *http.Request) ([]map[string]interface{}, bool, error) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		props, err := decodeJSON(data)
+		if err != nil {
+			return nil, false, err
+		}
+		return []map[string]interface{}{props}, false, nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, true, err
+	}
+	objects := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		props, err := decodeJSON(item)
+		if err != nil {
+			return nil, true, err
+		}
+		objects = append(objects, props)
+	}
+
+	return objects, true, nil
+}
+
+func decodeProperties(r *http.Request) (map[string]interface{}, error) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON(data)
+}
+
+// decodeJSON decodes a single JSON object, keeping numbers as json.Number.
+func decodeJSON(data []byte) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var props map[string]interface{}
+	if err := dec.Decode(&props); err != nil {
+		return nil, err
+	}
+	if props == nil {
+		return nil, errors.New("expected a JSON object")
+	}
+
+	return props, nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	body := &bytes.Buffer{}
+	if err := json.NewEncoder(body).Encode(v); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body.Bytes())
+}
+
+// result represents a (error) result in the format used by KEPServerEX.
+type result struct {
+	Code        int    `json:"code"`
+	Message     string `json:"message"`
+	Property    string `json:"property,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// validationError returns the result KEPServerEX returns when the given
+// property failed validation.
+func validationError(property, description string) *result {
+	return &result{
+		Code:        http.StatusBadRequest,
+		Message:     fmt.Sprintf("Validation failed on property %s in object definition: %s", property, description),
+		Property:    property,
+		Description: description,
+	}
+}
+
+// writeError writes an error in the format used by KEPServerEX.
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, &result{Code: code, Message: message})
+}
+
+// writeValidationError writes a validation error for the given property.
+func writeValidationError(w http.ResponseWriter, property, description string) {
+	writeJSON(w, http.StatusBadRequest, validationError(property, description))
+}
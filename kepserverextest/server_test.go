@@ -0,0 +1,567 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverextest_test
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	kepserverex "github.com/svanharmelen/go-kepserverex"
+	"github.com/svanharmelen/go-kepserverex/kepserverextest"
+)
+
+// newTestClient starts a new fake server and returns a client for it.
+func newTestClient(t *testing.T) (*kepserverextest.Server, *kepserverex.Client) {
+	s := kepserverextest.NewServer("user", "secret")
+	c, err := s.NewClient()
+	if err != nil {
+		s.Close()
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return s, c
+}
+
+// mustCreateChannel creates a channel using the given driver.
+func mustCreateChannel(t *testing.T, c *kepserverex.Client, name, driver string) {
+	err := c.Channels.CreateChannel(&kepserverex.ChannelOptions{
+		Name:   name,
+		Driver: driver,
+	})
+	if err != nil {
+		t.Fatalf("CreateChannel returned error: %v", err)
+	}
+}
+
+func TestChannels(t *testing.T) {
+	s, c := newTestClient(t)
+	defer s.Close()
+
+	mustCreateChannel(t, c, "Channel1", kepserverex.SimulatorDriver)
+
+	channels, err := c.Channels.ListChannels()
+	if err != nil {
+		t.Fatalf("ListChannels returned error: %v", err)
+	}
+	if len(channels) != 1 || channels[0].Name != "Channel1" {
+		t.Fatalf("ListChannels returned %+v, want a single Channel1", channels)
+	}
+
+	err = c.Channels.UpdateChannel("Channel1", &kepserverex.ChannelOptions{
+		Description: "Updated",
+	})
+	if err != nil {
+		t.Fatalf("UpdateChannel returned error: %v", err)
+	}
+
+	channel, err := c.Channels.GetChannel("Channel1")
+	if err != nil {
+		t.Fatalf("GetChannel returned error: %v", err)
+	}
+	if channel.Description != "Updated" || channel.Driver != kepserverex.SimulatorDriver {
+		t.Errorf("GetChannel returned %+v, want the updated description and the driver", channel)
+	}
+	if channel.ProjectID != s.ProjectID() {
+		t.Errorf("GetChannel returned project ID %d, want %d", channel.ProjectID, s.ProjectID())
+	}
+
+	if err := c.Channels.DeleteChannel("Channel1"); err != nil {
+		t.Fatalf("DeleteChannel returned error: %v", err)
+	}
+	if _, err := c.Channels.GetChannel("Channel1"); !errors.Is(err, kepserverex.ErrNotFound) {
+		t.Errorf("GetChannel after delete returned error %v, want %v", err, kepserverex.ErrNotFound)
+	}
+}
+
+func TestDevices(t *testing.T) {
+	tests := []struct {
+		driver string
+		create func(c *kepserverex.Client, name string) error
+		get    func(c *kepserverex.Client, name string) (string, error)
+		update func(c *kepserverex.Client, name, description string) error
+	}{
+		{
+			driver: kepserverex.ControlLogixEthernetDriver,
+			create: func(c *kepserverex.Client, name string) error {
+				return c.Devices.CreateControlLogixEthernetDevice("Channel1", &kepserverex.ControlLogixEthernetDeviceOptions{
+					Name:   name,
+					Driver: kepserverex.ControlLogixEthernetDriver,
+				})
+			},
+			get: func(c *kepserverex.Client, name string) (string, error) {
+				d, err := c.Devices.GetControlLogixEthernetDevice("Channel1", name)
+				if err != nil {
+					return "", err
+				}
+				return d.Description, nil
+			},
+			update: func(c *kepserverex.Client, name, description string) error {
+				return c.Devices.UpdateControlLogixEthernetDevice("Channel1", name, &kepserverex.ControlLogixEthernetDeviceOptions{
+					Description: description,
+					Driver:      kepserverex.ControlLogixEthernetDriver,
+				})
+			},
+		},
+		{
+			driver: kepserverex.OPCUAClientDriver,
+			create: func(c *kepserverex.Client, name string) error {
+				return c.Devices.CreateOPCUAClientDevice("Channel1", &kepserverex.OPCUAClientDeviceOptions{Name: name})
+			},
+			get: func(c *kepserverex.Client, name string) (string, error) {
+				d, err := c.Devices.GetOPCUAClientDevice("Channel1", name)
+				if err != nil {
+					return "", err
+				}
+				return d.Description, nil
+			},
+			update: func(c *kepserverex.Client, name, description string) error {
+				return c.Devices.UpdateOPCUAClientDevice("Channel1", name, &kepserverex.OPCUAClientDeviceOptions{
+					Description: description,
+				})
+			},
+		},
+		{
+			driver: kepserverex.SiemensS5AS511Driver,
+			create: func(c *kepserverex.Client, name string) error {
+				return c.Devices.CreateSiemensS5AS511Device("Channel1", &kepserverex.SiemensS5AS511DeviceOptions{
+					Name:   name,
+					Driver: kepserverex.SiemensS5AS511Driver,
+				})
+			},
+			get: func(c *kepserverex.Client, name string) (string, error) {
+				d, err := c.Devices.GetSiemensS5AS511Device("Channel1", name)
+				if err != nil {
+					return "", err
+				}
+				return d.Description, nil
+			},
+			update: func(c *kepserverex.Client, name, description string) error {
+				return c.Devices.UpdateSiemensS5AS511Device("Channel1", name, &kepserverex.SiemensS5AS511DeviceOptions{
+					Description: description,
+					Driver:      kepserverex.SiemensS5AS511Driver,
+				})
+			},
+		},
+		{
+			driver: kepserverex.SiemensTCPIPEthernetDriver,
+			create: func(c *kepserverex.Client, name string) error {
+				return c.Devices.CreateSiemensTCPIPEthernetDevice("Channel1", &kepserverex.SiemensTCPIPEthernetDeviceOptions{
+					Name:   name,
+					Driver: kepserverex.SiemensTCPIPEthernetDriver,
+				})
+			},
+			get: func(c *kepserverex.Client, name string) (string, error) {
+				d, err := c.Devices.GetSiemensTCPIPEthernetDevice("Channel1", name)
+				if err != nil {
+					return "", err
+				}
+				return d.Description, nil
+			},
+			update: func(c *kepserverex.Client, name, description string) error {
+				return c.Devices.UpdateSiemensTCPIPEthernetDevice("Channel1", name, &kepserverex.SiemensTCPIPEthernetDeviceOptions{
+					Description: description,
+					Driver:      kepserverex.SiemensTCPIPEthernetDriver,
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			s, c := newTestClient(t)
+			defer s.Close()
+
+			mustCreateChannel(t, c, "Channel1", tt.driver)
+
+			if err := tt.create(c, "Device1"); err != nil {
+				t.Fatalf("Create returned error: %v", err)
+			}
+			if err := tt.update(c, "Device1", "Updated"); err != nil {
+				t.Fatalf("Update returned error: %v", err)
+			}
+
+			description, err := tt.get(c, "Device1")
+			if err != nil {
+				t.Fatalf("Get returned error: %v", err)
+			}
+			if description != "Updated" {
+				t.Errorf("Get returned description %q, want %q", description, "Updated")
+			}
+
+			devices, err := c.Devices.ListDevices("Channel1")
+			if err != nil {
+				t.Fatalf("ListDevices returned error: %v", err)
+			}
+			if len(devices) != 1 || devices[0].Name != "Device1" || devices[0].Driver != tt.driver {
+				t.Fatalf("ListDevices returned %+v, want a single Device1", devices)
+			}
+
+			if err := c.Devices.DeleteDevice("Channel1", "Device1"); err != nil {
+				t.Fatalf("DeleteDevice returned error: %v", err)
+			}
+			if _, err := tt.get(c, "Device1"); !errors.Is(err, kepserverex.ErrNotFound) {
+				t.Errorf("Get after delete returned error %v, want %v", err, kepserverex.ErrNotFound)
+			}
+		})
+	}
+}
+
+func TestTagGroups(t *testing.T) {
+	s, c := newTestClient(t)
+	defer s.Close()
+
+	mustCreateChannel(t, c, "Channel1", kepserverex.SimulatorDriver)
+	if err := c.Devices.CreateSimulatorDevice("Channel1", &kepserverex.SimulatorDeviceOptions{Name: "Device1"}); err != nil {
+		t.Fatalf("CreateSimulatorDevice returned error: %v", err)
+	}
+
+	err := c.TagGroups.CreatetagGroup("Channel1", "Device1", &kepserverex.TagGroupOptions{
+		Name: "Group1",
+	})
+	if err != nil {
+		t.Fatalf("CreatetagGroup returned error: %v", err)
+	}
+
+	err = c.TagGroups.UpdateTagGroup("Channel1", "Device1", "Group1", &kepserverex.TagGroupOptions{
+		Description: "Updated",
+	})
+	if err != nil {
+		t.Fatalf("UpdateTagGroup returned error: %v", err)
+	}
+
+	group, err := c.TagGroups.GetTagGroup("Channel1", "Device1", "Group1")
+	if err != nil {
+		t.Fatalf("GetTagGroup returned error: %v", err)
+	}
+	if group.Description != "Updated" {
+		t.Errorf("GetTagGroup returned description %q, want %q", group.Description, "Updated")
+	}
+
+	groups, err := c.TagGroups.ListTagGroups("Channel1", "Device1")
+	if err != nil {
+		t.Fatalf("ListTagGroups returned error: %v", err)
+	}
+	if len(groups) != 1 || groups[0].Name != "Group1" {
+		t.Fatalf("ListTagGroups returned %+v, want a single Group1", groups)
+	}
+
+	if err := c.TagGroups.DeleteTagGroup("Channel1", "Device1", "Group1"); err != nil {
+		t.Fatalf("DeleteTagGroup returned error: %v", err)
+	}
+	if _, err := c.TagGroups.GetTagGroup("Channel1", "Device1", "Group1"); !errors.Is(err, kepserverex.ErrNotFound) {
+		t.Errorf("GetTagGroup after delete returned error %v, want %v", err, kepserverex.ErrNotFound)
+	}
+}
+
+func TestTags(t *testing.T) {
+	s, c := newTestClient(t)
+	defer s.Close()
+
+	mustCreateChannel(t, c, "Channel1", kepserverex.SimulatorDriver)
+	if err := c.Devices.CreateSimulatorDevice("Channel1", &kepserverex.SimulatorDeviceOptions{Name: "Device1"}); err != nil {
+		t.Fatalf("CreateSimulatorDevice returned error: %v", err)
+	}
+
+	dataType := kepserverex.DataType_Word
+	err := c.Tags.CreateTag("Channel1", "Device1", "", &kepserverex.TagOptions{
+		Name:     kepserverex.String("Tag1"),
+		Address:  kepserverex.String("K0001"),
+		DataType: &dataType,
+	})
+	if err != nil {
+		t.Fatalf("CreateTag returned error: %v", err)
+	}
+
+	err = c.Tags.UpdateTag("Channel1", "Device1", "", "Tag1", &kepserverex.TagOptions{
+		Description: kepserverex.String("Updated"),
+	})
+	if err != nil {
+		t.Fatalf("UpdateTag returned error: %v", err)
+	}
+
+	tag, err := c.Tags.GetTag("Channel1", "Device1", "", "Tag1")
+	if err != nil {
+		t.Fatalf("GetTag returned error: %v", err)
+	}
+	if tag.Description != "Updated" || tag.Address != "K0001" || tag.DataType != kepserverex.DataType_Word {
+		t.Errorf("GetTag returned %+v, want the updated description and the address", tag)
+	}
+
+	tags, err := c.Tags.ListTags("Channel1", "Device1", "")
+	if err != nil {
+		t.Fatalf("ListTags returned error: %v", err)
+	}
+	if len(tags) != 1 || tags[0].Name != "Tag1" {
+		t.Fatalf("ListTags returned %+v, want a single Tag1", tags)
+	}
+
+	if err := c.Tags.DeleteTag("Channel1", "Device1", "", "Tag1"); err != nil {
+		t.Fatalf("DeleteTag returned error: %v", err)
+	}
+	if _, err := c.Tags.GetTag("Channel1", "Device1", "", "Tag1"); !errors.Is(err, kepserverex.ErrNotFound) {
+		t.Errorf("GetTag after delete returned error %v, want %v", err, kepserverex.ErrNotFound)
+	}
+}
+
+func TestProjectChanged(t *testing.T) {
+	s, c := newTestClient(t)
+	defer s.Close()
+
+	mustCreateChannel(t, c, "Channel1", kepserverex.SimulatorDriver)
+	if err := c.Devices.CreateSimulatorDevice("Channel1", &kepserverex.SimulatorDeviceOptions{Name: "Device1"}); err != nil {
+		t.Fatalf("CreateSimulatorDevice returned error: %v", err)
+	}
+	err := c.Tags.CreateTag("Channel1", "Device1", "", &kepserverex.TagOptions{
+		Name:    kepserverex.String("Tag1"),
+		Address: kepserverex.String("K0001"),
+	})
+	if err != nil {
+		t.Fatalf("CreateTag returned error: %v", err)
+	}
+
+	tag, err := c.Tags.GetTag("Channel1", "Device1", "", "Tag1")
+	if err != nil {
+		t.Fatalf("GetTag returned error: %v", err)
+	}
+	if tag.ProjectID != s.ProjectID() {
+		t.Fatalf("GetTag returned project ID %d, want %d", tag.ProjectID, s.ProjectID())
+	}
+
+	// Change the project after the tag was read.
+	mustCreateChannel(t, c, "Channel2", kepserverex.SimulatorDriver)
+
+	err = c.Tags.UpdateTag("Channel1", "Device1", "", "Tag1", &kepserverex.TagOptions{
+		ProjectID:   tag.ProjectID,
+		Description: kepserverex.String("Stale"),
+	})
+	if !errors.Is(err, kepserverex.ErrProjectChanged) {
+		t.Fatalf("UpdateTag returned error %v, want %v", err, kepserverex.ErrProjectChanged)
+	}
+	if !errors.Is(err, kepserverex.ErrConflict) {
+		t.Errorf("UpdateTag returned error %v, want it to match %v", err, kepserverex.ErrConflict)
+	}
+	var errResp *kepserverex.ErrorResponse
+	if !errors.As(err, &errResp) || len(errResp.Properties) != 1 || errResp.Properties[0].Property != "PROJECT_ID" {
+		t.Errorf("UpdateTag returned error %v, want a PROJECT_ID property error", err)
+	}
+
+	stale := tag.ProjectID
+	attempts := 0
+	err = kepserverex.RetryOnProjectChange(3, func() error {
+		attempts++
+		projectID := stale
+		if attempts > 1 {
+			tag, err := c.Tags.GetTag("Channel1", "Device1", "", "Tag1")
+			if err != nil {
+				return err
+			}
+			projectID = tag.ProjectID
+		}
+		return c.Tags.UpdateTag("Channel1", "Device1", "", "Tag1", &kepserverex.TagOptions{
+			ProjectID:   projectID,
+			Description: kepserverex.String("Updated"),
+		})
+	})
+	if err != nil {
+		t.Fatalf("RetryOnProjectChange returned error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("RetryOnProjectChange made %d attempts, want 2", attempts)
+	}
+
+	tag, err = c.Tags.GetTag("Channel1", "Device1", "", "Tag1")
+	if err != nil {
+		t.Fatalf("GetTag returned error: %v", err)
+	}
+	if tag.Description != "Updated" {
+		t.Errorf("GetTag returned description %q, want %q", tag.Description, "Updated")
+	}
+
+	// Retrying gives up after the given number of attempts.
+	attempts = 0
+	err = kepserverex.RetryOnProjectChange(2, func() error {
+		attempts++
+		return c.Channels.UpdateChannel("Channel1", &kepserverex.ChannelOptions{
+			ProjectID:   stale,
+			Description: "Stale",
+		})
+	})
+	if !errors.Is(err, kepserverex.ErrProjectChanged) || attempts != 2 {
+		t.Errorf("RetryOnProjectChange returned error %v after %d attempts, want %v after 2",
+			err, attempts, kepserverex.ErrProjectChanged)
+	}
+}
+
+// countingTransport counts the requests sent per method.
+type countingTransport struct {
+	mu      sync.Mutex
+	methods map[string]int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.methods[req.Method]++
+	t.mu.Unlock()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestBulkCreate(t *testing.T) {
+	s := kepserverextest.NewServer("user", "secret")
+	defer s.Close()
+
+	transport := &countingTransport{methods: make(map[string]int)}
+	c, err := kepserverex.NewClient(&http.Client{Transport: transport}, "", s.Username, s.Password)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := c.SetBaseURL(s.URL); err != nil {
+		t.Fatalf("Failed to set base URL: %v", err)
+	}
+	c.SetBulkChunkSize(2)
+
+	mustCreateChannel(t, c, "Channel1", kepserverex.SimulatorDriver)
+	if err := c.Devices.CreateSimulatorDevice("Channel1", &kepserverex.SimulatorDeviceOptions{Name: "Device1"}); err != nil {
+		t.Fatalf("CreateSimulatorDevice returned error: %v", err)
+	}
+	if err := c.Tags.CreateTag("Channel1", "Device1", "", &kepserverex.TagOptions{Name: kepserverex.String("Tag3")}); err != nil {
+		t.Fatalf("CreateTag returned error: %v", err)
+	}
+	transport.methods = make(map[string]int)
+
+	var opts []*kepserverex.TagOptions
+	for _, name := range []string{"Tag1", "Tag2", "Tag3", "Tag4", "Tag5"} {
+		opts = append(opts, &kepserverex.TagOptions{
+			Name:    kepserverex.String(name),
+			Address: kepserverex.String("K0001"),
+		})
+	}
+
+	results, err := c.Tags.CreateTags("Channel1", "Device1", "", opts)
+	if err != nil {
+		t.Fatalf("CreateTags returned error: %v", err)
+	}
+
+	// 5 tags in chunks of 2 take 3 requests.
+	if n := transport.methods["POST"]; n != 3 {
+		t.Errorf("CreateTags sent %d requests, want 3", n)
+	}
+
+	if len(results) != len(opts) {
+		t.Fatalf("CreateTags returned %d results, want %d", len(results), len(opts))
+	}
+	for i, r := range results {
+		name := *opts[i].Name
+		if r.Name != name {
+			t.Errorf("result %d is for %q, want %q", i, r.Name, name)
+		}
+		switch {
+		case name == "Tag3" && !errors.Is(r.Err, kepserverex.ErrAlreadyExists):
+			t.Errorf("result for %s has error %v, want %v", name, r.Err, kepserverex.ErrAlreadyExists)
+		case name != "Tag3" && r.Err != nil:
+			t.Errorf("result for %s has error %v, want none", name, r.Err)
+		}
+	}
+
+	tags, err := c.Tags.ListTags("Channel1", "Device1", "")
+	if err != nil {
+		t.Fatalf("ListTags returned error: %v", err)
+	}
+	if len(tags) != 5 {
+		t.Errorf("ListTags returned %d tags, want 5", len(tags))
+	}
+}
+
+func TestTagsByPath(t *testing.T) {
+	s, c := newTestClient(t)
+	defer s.Close()
+
+	mustCreateChannel(t, c, "Channel 1", kepserverex.SimulatorDriver)
+	if err := c.Devices.CreateSimulatorDevice("Channel 1", &kepserverex.SimulatorDeviceOptions{Name: "Device1"}); err != nil {
+		t.Fatalf("CreateSimulatorDevice returned error: %v", err)
+	}
+
+	// Create a nested tag group with names needing escaping.
+	path, err := kepserverex.ParseTagPath("Channel 1.Device1")
+	if err != nil {
+		t.Fatalf("ParseTagPath returned error: %v", err)
+	}
+	for _, group := range []string{"Group 1", "Sub/1"} {
+		if err := c.TagGroups.CreateTagGroupByPath(path, &kepserverex.TagGroupOptions{Name: group}); err != nil {
+			t.Fatalf("CreateTagGroupByPath(%v) returned error: %v", path, err)
+		}
+		path = path.Child(group)
+	}
+
+	tagPath, name, err := kepserverex.ParseTagName("Channel 1.Device1.Group 1.Sub/1.Tag1")
+	if err != nil {
+		t.Fatalf("ParseTagName returned error: %v", err)
+	}
+	if tagPath.String() != path.String() {
+		t.Fatalf("ParseTagName returned path %v, want %v", tagPath, path)
+	}
+
+	err = c.Tags.CreateTagByPath(tagPath, &kepserverex.TagOptions{
+		Name:    kepserverex.String(name),
+		Address: kepserverex.String("K0001"),
+	})
+	if err != nil {
+		t.Fatalf("CreateTagByPath returned error: %v", err)
+	}
+
+	err = c.Tags.UpdateTagByPath(tagPath, name, &kepserverex.TagOptions{
+		Description: kepserverex.String("Updated"),
+	})
+	if err != nil {
+		t.Fatalf("UpdateTagByPath returned error: %v", err)
+	}
+
+	tag, err := c.Tags.GetTagByPath(tagPath, name)
+	if err != nil {
+		t.Fatalf("GetTagByPath returned error: %v", err)
+	}
+	if tag.Name != "Tag1" || tag.Description != "Updated" {
+		t.Errorf("GetTagByPath returned %+v, want the updated Tag1", tag)
+	}
+
+	// The tag must only exist within the nested group.
+	groups, err := c.TagGroups.ListTagGroupsByPath(tagPath.Child("Missing"))
+	if !errors.Is(err, kepserverex.ErrNotFound) {
+		t.Errorf("ListTagGroupsByPath for a missing group returned %+v and error %v, want %v", groups, err, kepserverex.ErrNotFound)
+	}
+	rootTags, err := c.Tags.ListTagsByPath(kepserverex.NewTagPath("Channel 1", "Device1"))
+	if err != nil {
+		t.Fatalf("ListTagsByPath returned error: %v", err)
+	}
+	if len(rootTags) != 0 {
+		t.Errorf("ListTagsByPath for the device returned %d tags, want none", len(rootTags))
+	}
+	tags, err := c.Tags.ListTagsByPath(tagPath)
+	if err != nil {
+		t.Fatalf("ListTagsByPath returned error: %v", err)
+	}
+	if len(tags) != 1 || tags[0].Name != "Tag1" {
+		t.Fatalf("ListTagsByPath returned %+v, want a single Tag1", tags)
+	}
+
+	if err := c.Tags.DeleteTagByPath(tagPath, name); err != nil {
+		t.Fatalf("DeleteTagByPath returned error: %v", err)
+	}
+	if _, err := c.Tags.GetTagByPath(tagPath, name); !errors.Is(err, kepserverex.ErrNotFound) {
+		t.Errorf("GetTagByPath after delete returned error %v, want %v", err, kepserverex.ErrNotFound)
+	}
+}
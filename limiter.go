@@ -0,0 +1,196 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Limit configures the limits applied to either reads or writes.
+type Limit struct {
+	// MaxConcurrent is the maximum number of requests in-flight at the same
+	// time. Use 1 to serialize all requests. Zero means no limit.
+	MaxConcurrent int
+
+	// PerSecond is the maximum number of requests started per second.
+	// Zero means no limit.
+	PerSecond float64
+}
+
+// A Limiter limits the number of concurrent requests and the request rate,
+// separately for reads (GET, HEAD and OPTIONS requests) and writes. Requests
+// waiting for the limiter are handled in order. A Limiter is safe for
+// concurrent use and can be shared by multiple clients to share the limits.
+type Limiter struct {
+	reads, writes *limiter
+}
+
+// NewLimiter returns a new Limiter using the given limits for reads and
+// writes.
+func NewLimiter(reads, writes Limit) *Limiter {
+	return &Limiter{
+		reads:  newLimiter(reads),
+		writes: newLimiter(writes),
+	}
+}
+
+// SetLimiter sets the limiter used to limit the requests made by the client.
+// A nil limiter disables limiting, which is the default.
+func (c *Client) SetLimiter(l *Limiter) {
+	c.limiter = l
+}
+
+// acquire waits until the request can be sent without exceeding the maximum
+// number of concurrent requests. It is called for every attempt, including
+// retries, and the returned func must be called when the attempt is done.
+func (l *Limiter) acquire(req *http.Request) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	return l.forRequest(req).acquire(req)
+}
+
+// wait waits until the request can be sent without exceeding the request
+// rate. It is called for every attempt, including retries.
+func (l *Limiter) wait(req *http.Request) error {
+	if l == nil {
+		return nil
+	}
+	return l.forRequest(req).wait(req)
+}
+
+func (l *Limiter) forRequest(req *http.Request) *limiter {
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS":
+		return l.reads
+	}
+	return l.writes
+}
+
+// limiter implements a single Limit.
+type limiter struct {
+	max      int
+	interval time.Duration
+
+	mu      sync.Mutex
+	active  int
+	waiters []chan struct{}
+	next    time.Time
+}
+
+func newLimiter(limit Limit) *limiter {
+	l := &limiter{max: limit.MaxConcurrent}
+	if limit.PerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / limit.PerSecond)
+	}
+	return l
+}
+
+// acquire takes one of the concurrency slots, waiting for one to be released
+// if there are none left. Waiting requests are queued, so slots are handed
+// out in the order in which they were requested.
+func (l *limiter) acquire(req *http.Request) (func(), error) {
+	if l.max <= 0 {
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	if l.active < l.max && len(l.waiters) == 0 {
+		l.active++
+		l.mu.Unlock()
+		return l.releaseFunc(), nil
+	}
+	ready := make(chan struct{})
+	l.waiters = append(l.waiters, ready)
+	l.mu.Unlock()
+
+	select {
+	case <-ready:
+		return l.releaseFunc(), nil
+	case <-req.Context().Done():
+	}
+
+	l.mu.Lock()
+	for i, w := range l.waiters {
+		if w == ready {
+			l.waiters = append(l.waiters[:i], l.waiters[i+1:]...)
+			l.mu.Unlock()
+			return nil, req.Context().Err()
+		}
+	}
+	l.mu.Unlock()
+
+	// The slot was handed to us after the context was canceled, so
+	// pass it on to the next request in line.
+	l.release()
+	return nil, req.Context().Err()
+}
+
+// releaseFunc returns a func releasing the slot, which is safe to call more
+// than once.
+func (l *limiter) releaseFunc() func() {
+	var once sync.Once
+	return func() { once.Do(l.release) }
+}
+
+// release hands the slot to the first waiting request, or frees it when
+// there are none.
+func (l *limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.waiters) > 0 {
+		close(l.waiters[0])
+		l.waiters = l.waiters[1:]
+		return
+	}
+	l.active--
+}
+
+// wait reserves the next free slot and waits until it has come. Requests
+// are spaced evenly by reserving slots one interval apart.
+func (l *limiter) wait(req *http.Request) error {
+	if l.interval == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	wait := time.Until(at)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
@@ -0,0 +1,285 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func waiters(l *limiter) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.waiters)
+}
+
+func TestLimiterOrder(t *testing.T) {
+	l := newLimiter(Limit{MaxConcurrent: 1})
+	req, _ := http.NewRequest("GET", "/", nil)
+
+	release, err := l.acquire(req)
+	if err != nil {
+		t.Fatalf("acquire returned error: %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []int
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			release, err := l.acquire(req)
+			if err != nil {
+				t.Errorf("acquire returned error: %v", err)
+				return
+			}
+			mu.Lock()
+			order = append(order, i)
+			mu.Unlock()
+			release()
+		}(i)
+
+		// Wait until the request is queued before starting the next one.
+		for waiters(l) != i+1 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	release()
+	wg.Wait()
+
+	for i, n := range order {
+		if n != i {
+			t.Fatalf("requests acquired the limiter in order %v, want 0 to 9", order)
+		}
+	}
+}
+
+func TestLimiterCanceledWaiter(t *testing.T) {
+	l := newLimiter(Limit{MaxConcurrent: 1})
+	req, _ := http.NewRequest("GET", "/", nil)
+
+	release, err := l.acquire(req)
+	if err != nil {
+		t.Fatalf("acquire returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := l.acquire(req.WithContext(ctx)); err != context.DeadlineExceeded {
+		t.Fatalf("acquire returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if n := waiters(l); n != 0 {
+		t.Fatalf("limiter has %d waiters, want 0", n)
+	}
+
+	release()
+	release()
+
+	// The slot must be free again, and releasing twice must not have
+	// freed more than one slot.
+	if _, err := l.acquire(req); err != nil {
+		t.Fatalf("acquire returned error: %v", err)
+	}
+	if l.active != 1 {
+		t.Fatalf("limiter has %d active requests, want 1", l.active)
+	}
+}
+
+func TestLimiterMaxConcurrent(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+
+	mux.HandleFunc("/config/v1/project/channels", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		io.WriteString(w, `[]`)
+	})
+
+	client.SetLimiter(NewLimiter(Limit{MaxConcurrent: 2}, Limit{}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Channels.ListChannels(); err != nil {
+				t.Errorf("ListChannels returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 2 {
+		t.Errorf("maximum number of requests in-flight was %d, want 2", maxInFlight)
+	}
+}
+
+func TestLimiterPerSecond(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	mux.HandleFunc("/config/v1/project/channels", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `[]`)
+	})
+
+	client.SetLimiter(NewLimiter(Limit{PerSecond: 50}, Limit{}))
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Channels.ListChannels(); err != nil {
+				t.Errorf("ListChannels returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The first request is sent right away, the other 9 are spaced 20ms
+	// apart.
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("10 requests took %v, want at least 180ms", elapsed)
+	}
+}
+
+func TestLimiterReadsAndWrites(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	block := make(chan struct{})
+
+	mux.HandleFunc("/config/v1/project/channels", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			<-block
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		io.WriteString(w, `[]`)
+	})
+
+	client.SetLimiter(NewLimiter(Limit{MaxConcurrent: 1}, Limit{MaxConcurrent: 1}))
+
+	done := make(chan error)
+	go func() {
+		done <- client.Channels.CreateChannel(&ChannelOptions{Name: "Channel1"})
+	}()
+
+	// The pending write must not block reads.
+	for i := 0; i < 3; i++ {
+		if _, err := client.Channels.ListChannels(); err != nil {
+			t.Fatalf("ListChannels returned error: %v", err)
+		}
+	}
+
+	close(block)
+	if err := <-done; err != nil {
+		t.Fatalf("CreateChannel returned error: %v", err)
+	}
+}
+
+func TestLimiterReleasedBetweenRetries(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	var mu sync.Mutex
+	var hits []string
+
+	first := true
+	mux.HandleFunc("/config/v1/project/channels/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits = append(hits, r.URL.Path)
+		retry := first && r.URL.Path == "/config/v1/project/channels/Channel1"
+		first = first && !retry
+		mu.Unlock()
+
+		if retry {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		io.WriteString(w, `{}`)
+	})
+
+	client.SetLimiter(NewLimiter(Limit{MaxConcurrent: 1}, Limit{}))
+	client.SetRetryPolicy(&RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  200 * time.Millisecond,
+		MaxBackoff:  200 * time.Millisecond,
+	})
+
+	done := make(chan error)
+	go func() {
+		_, err := client.Channels.GetChannel("Channel1")
+		done <- err
+	}()
+
+	// Wait for the first attempt, after which Channel1 is backing off.
+	for {
+		mu.Lock()
+		n := len(hits)
+		mu.Unlock()
+		if n == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, err := client.Channels.GetChannel("Channel2"); err != nil {
+		t.Fatalf("GetChannel returned error: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("GetChannel returned error: %v", err)
+	}
+
+	want := []string{
+		"/config/v1/project/channels/Channel1",
+		"/config/v1/project/channels/Channel2",
+		"/config/v1/project/channels/Channel1",
+	}
+	if len(hits) != len(want) {
+		t.Fatalf("server received %v, want %v", hits, want)
+	}
+	for i := range want {
+		if hits[i] != want[i] {
+			t.Fatalf("server received %v, want %v", hits, want)
+		}
+	}
+}
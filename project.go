@@ -0,0 +1,407 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+const (
+	projectIDProperty = "PROJECT_ID"
+	nameProperty      = "common.ALLTYPES_NAME"
+)
+
+// Project represents a complete KEPServerEX project tree. It marshals to
+// and from the same JSON structure KEPServerEX uses for serialized content.
+type Project struct {
+	ProjectID  int64
+	Properties Properties
+	Channels   []*ProjectChannel
+}
+
+// ProjectChannel represents a channel and all its devices.
+type ProjectChannel struct {
+	Properties Properties
+	Devices    []*ProjectDevice
+}
+
+// ProjectDevice represents a device and all its tag groups and tags.
+type ProjectDevice struct {
+	Properties Properties
+	TagGroups  []*ProjectTagGroup
+	Tags       []Properties
+}
+
+// ProjectTagGroup represents a tag group and all its tag groups and tags.
+type ProjectTagGroup struct {
+	Properties Properties
+	TagGroups  []*ProjectTagGroup
+	Tags       []Properties
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (p *Project) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.merge(p.Properties))
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (p *Project) MarshalYAML() (interface{}, error) {
+	return p.merge(p.Properties.native()), nil
+}
+
+func (p *Project) merge(props Properties) map[string]interface{} {
+	m := mergeProperties(props)
+	m[projectIDProperty] = p.ProjectID
+	if len(p.Channels) > 0 {
+		m["channels"] = p.Channels
+	}
+	return m
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *Project) UnmarshalJSON(data []byte) error {
+	props, err := unmarshalProperties(data, map[string]interface{}{"channels": &p.Channels})
+	if err != nil {
+		return err
+	}
+	if id, ok := props[projectIDProperty].(json.Number); ok {
+		if p.ProjectID, err = id.Int64(); err != nil {
+			return err
+		}
+	}
+	delete(props, projectIDProperty)
+	p.Properties = props
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c *ProjectChannel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.merge(c.Properties))
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (c *ProjectChannel) MarshalYAML() (interface{}, error) {
+	return c.merge(c.Properties.native()), nil
+}
+
+func (c *ProjectChannel) merge(props Properties) map[string]interface{} {
+	m := mergeProperties(props)
+	if len(c.Devices) > 0 {
+		m["devices"] = c.Devices
+	}
+	return m
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *ProjectChannel) UnmarshalJSON(data []byte) (err error) {
+	c.Properties, err = unmarshalProperties(data, map[string]interface{}{"devices": &c.Devices})
+	delete(c.Properties, projectIDProperty)
+	return err
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d *ProjectDevice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(mergeContainer(d.Properties, d.TagGroups, d.Tags))
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (d *ProjectDevice) MarshalYAML() (interface{}, error) {
+	return mergeContainer(d.Properties.native(), d.TagGroups, d.Tags), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *ProjectDevice) UnmarshalJSON(data []byte) (err error) {
+	d.Properties, err = unmarshalProperties(data, map[string]interface{}{
+		"tag_groups": &d.TagGroups,
+		"tags":       &d.Tags,
+	})
+	delete(d.Properties, projectIDProperty)
+	for _, tag := range d.Tags {
+		delete(tag, projectIDProperty)
+	}
+	return err
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (g *ProjectTagGroup) MarshalJSON() ([]byte, error) {
+	return json.Marshal(mergeContainer(g.Properties, g.TagGroups, g.Tags))
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (g *ProjectTagGroup) MarshalYAML() (interface{}, error) {
+	return mergeContainer(g.Properties.native(), g.TagGroups, g.Tags), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (g *ProjectTagGroup) UnmarshalJSON(data []byte) (err error) {
+	g.Properties, err = unmarshalProperties(data, map[string]interface{}{
+		"tag_groups": &g.TagGroups,
+		"tags":       &g.Tags,
+	})
+	delete(g.Properties, projectIDProperty)
+	for _, tag := range g.Tags {
+		delete(tag, projectIDProperty)
+	}
+	return err
+}
+
+// mergeProperties returns a copy of the properties as a plain map, so
+// children can be added to it before marshaling.
+func mergeProperties(props Properties) map[string]interface{} {
+	m := make(map[string]interface{}, len(props)+2)
+	for k, v := range props {
+		m[k] = v
+	}
+	return m
+}
+
+// mergeContainer merges the properties, tag groups and tags of a device
+// or tag group into a single map.
+func mergeContainer(props Properties, groups []*ProjectTagGroup, tags []Properties) map[string]interface{} {
+	m := mergeProperties(props)
+	if len(groups) > 0 {
+		m["tag_groups"] = groups
+	}
+	if len(tags) > 0 {
+		m["tags"] = tags
+	}
+	return m
+}
+
+// unmarshalProperties decodes the given children into the values pointed to
+// by the children map and returns all remaining values as properties.
+func unmarshalProperties(data []byte, children map[string]interface{}) (Properties, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	for k, v := range children {
+		if r, ok := raw[k]; ok {
+			if err := json.Unmarshal(r, v); err != nil {
+				return nil, err
+			}
+			delete(raw, k)
+		}
+	}
+
+	// Re-encode the remaining values, so they can be decoded as properties.
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var props Properties
+	if err := json.Unmarshal(data, &props); err != nil {
+		return nil, err
+	}
+
+	return props, nil
+}
+
+// ExportProjectOptions represents the available ExportProject() options.
+type ExportProjectOptions struct {
+	// Concurrency limits the number of concurrent requests used while
+	// exporting the project. Defaults to 4 when not set.
+	Concurrency int
+
+	// DisableSerialize disables the use of the content=serialize query
+	// and forces a crawl of the complete project tree.
+	DisableSerialize bool
+}
+
+type serializeOptions struct {
+	Content string `url:"content,omitempty"`
+}
+
+// ExportProject exports the complete channel, device, tag group and tag
+// hierarchy of the project. Channels are requested with content=serialize
+// when possible, falling back to a concurrent crawl of the tree otherwise.
+func (c *Client) ExportProject(opt *ExportProjectOptions, options ...RequestOptionFunc) (*Project, error) {
+	if opt == nil {
+		opt = &ExportProjectOptions{}
+	}
+	concurrency := opt.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	e := &projectExporter{
+		client:  c,
+		options: options,
+		sem:     make(chan struct{}, concurrency),
+	}
+
+	p := new(Project)
+	if err := e.get("", nil, p); err != nil {
+		return nil, err
+	}
+
+	var channels []Properties
+	if err := e.get("channels", nil, &channels); err != nil {
+		return nil, err
+	}
+
+	p.Channels = make([]*ProjectChannel, len(channels))
+	for i, props := range channels {
+		delete(props, projectIDProperty)
+		p.Channels[i] = &ProjectChannel{Properties: props}
+
+		ch := p.Channels[i]
+		e.do(func() error {
+			if !opt.DisableSerialize {
+				ok, err := e.serializeChannel(ch)
+				if err != nil || ok {
+					return err
+				}
+			}
+			return e.crawlChannel(ch)
+		})
+	}
+
+	e.wg.Wait()
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	return p, nil
+}
+
+// projectExporter exports a project using a bounded number of concurrent
+// requests.
+type projectExporter struct {
+	client  *Client
+	options []RequestOptionFunc
+	sem     chan struct{}
+
+	wg  sync.WaitGroup
+	mu  sync.Mutex
+	err error
+}
+
+// get requests the given path and decodes the response into v, making
+// sure that no more than the configured number of requests run at once.
+func (e *projectExporter) get(path string, opt, v interface{}) error {
+	e.sem <- struct{}{}
+	defer func() { <-e.sem }()
+
+	req, err := e.client.NewRequest("GET", path, opt, e.options...)
+	if err != nil {
+		return err
+	}
+	return e.client.Do(req, v)
+}
+
+// do runs fn in a new goroutine, recording the first error returned.
+func (e *projectExporter) do(fn func() error) {
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		if err := fn(); err != nil {
+			e.mu.Lock()
+			if e.err == nil {
+				e.err = err
+			}
+			e.mu.Unlock()
+		}
+	}()
+}
+
+// serializeChannel requests the channel including all its content. It
+// returns false if the server did not return the serialized content, or
+// answered the request with an error status.
+func (e *projectExporter) serializeChannel(ch *ProjectChannel) (bool, error) {
+	u := fmt.Sprintf("channels/%s", url.PathEscape(ch.Properties.Name()))
+
+	body := &bytes.Buffer{}
+	if err := e.get(u, &serializeOptions{Content: "serialize"}, body); err != nil {
+		// Servers not supporting the serialize query may answer it with
+		// an error, in which case the channel is crawled instead.
+		var errResp *ErrorResponse
+		if errors.As(err, &errResp) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body.Bytes(), &raw); err != nil {
+		return false, err
+	}
+	if _, ok := raw["devices"]; !ok {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(body.Bytes(), ch)
+}
+
+func (e *projectExporter) crawlChannel(ch *ProjectChannel) error {
+	channel := ch.Properties.Name()
+
+	var devices []Properties
+	if err := e.get(fmt.Sprintf("channels/%s/devices", url.PathEscape(channel)), nil, &devices); err != nil {
+		return err
+	}
+
+	ch.Devices = make([]*ProjectDevice, len(devices))
+	for i, props := range devices {
+		delete(props, projectIDProperty)
+		ch.Devices[i] = &ProjectDevice{Properties: props}
+
+		d := ch.Devices[i]
+		path := NewTagPath(channel, props.Name())
+		e.do(func() error { return e.crawlTagGroups(path, &d.TagGroups) })
+		e.do(func() error { return e.crawlTags(path, &d.Tags) })
+	}
+
+	return nil
+}
+
+func (e *projectExporter) crawlTagGroups(path *TagPath, groups *[]*ProjectTagGroup) error {
+	var list []Properties
+	if err := e.get(path.collectionURL("tag_groups"), nil, &list); err != nil {
+		return err
+	}
+
+	*groups = make([]*ProjectTagGroup, len(list))
+	for i, props := range list {
+		delete(props, projectIDProperty)
+		(*groups)[i] = &ProjectTagGroup{Properties: props}
+
+		g := (*groups)[i]
+		child := path.Child(props.Name())
+		e.do(func() error { return e.crawlTagGroups(child, &g.TagGroups) })
+		e.do(func() error { return e.crawlTags(child, &g.Tags) })
+	}
+
+	return nil
+}
+
+func (e *projectExporter) crawlTags(path *TagPath, tags *[]Properties) error {
+	if err := e.get(path.collectionURL("tags"), nil, tags); err != nil {
+		return err
+	}
+	for _, props := range *tags {
+		delete(props, projectIDProperty)
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	kepserverex "github.com/svanharmelen/go-kepserverex"
+	"github.com/svanharmelen/go-kepserverex/kepserverextest"
+)
+
+// serializeTransport counts the requests using the serialize query and
+// optionally rejects them with the given error status.
+type serializeTransport struct {
+	status int
+
+	mu sync.Mutex
+	n  int
+}
+
+func (t *serializeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Query().Get("content") != "serialize" {
+		return http.DefaultTransport.RoundTrip(req)
+	}
+
+	t.mu.Lock()
+	t.n++
+	t.mu.Unlock()
+
+	if t.status == 0 {
+		return http.DefaultTransport.RoundTrip(req)
+	}
+	return &http.Response{
+		StatusCode: t.status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(`{"code":400,"message":"Invalid query parameter 'content'"}`)),
+		Request:    req,
+	}, nil
+}
+
+func (t *serializeTransport) count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.n
+}
+
+// newProject returns a fake server with a project containing nested tag
+// groups, and a client using the given transport.
+func newProject(t *testing.T, transport http.RoundTripper) (*kepserverextest.Server, *kepserverex.Client) {
+	s := kepserverextest.NewServer("user", "secret")
+
+	c, err := kepserverex.NewClient(&http.Client{Transport: transport}, "", s.Username, s.Password)
+	if err != nil {
+		s.Close()
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := c.SetBaseURL(s.URL); err != nil {
+		s.Close()
+		t.Fatalf("Failed to set base URL: %v", err)
+	}
+
+	for _, channel := range []string{"Channel1", "Channel2"} {
+		err := c.Channels.CreateChannel(&kepserverex.ChannelOptions{Name: channel, Driver: kepserverex.SimulatorDriver})
+		if err != nil {
+			s.Close()
+			t.Fatalf("CreateChannel returned error: %v", err)
+		}
+		if err := c.Devices.CreateSimulatorDevice(channel, &kepserverex.SimulatorDeviceOptions{Name: "Device1"}); err != nil {
+			s.Close()
+			t.Fatalf("CreateSimulatorDevice returned error: %v", err)
+		}
+	}
+
+	device := kepserverex.NewTagPath("Channel1", "Device1")
+	for _, group := range []*kepserverex.TagPath{device, device.Child("Group1")} {
+		if err := c.TagGroups.CreateTagGroupByPath(group, &kepserverex.TagGroupOptions{Name: "Group1"}); err != nil {
+			s.Close()
+			t.Fatalf("CreateTagGroupByPath returned error: %v", err)
+		}
+	}
+
+	tags := map[string]*kepserverex.TagPath{
+		"Tag1": device,
+		"Tag2": device.Child("Group1"),
+		"Tag3": device.Child("Group1").Child("Group1"),
+	}
+	for name, path := range tags {
+		err := c.Tags.CreateTagByPath(path, &kepserverex.TagOptions{
+			Name:    kepserverex.String(name),
+			Address: kepserverex.String("K0001"),
+		})
+		if err != nil {
+			s.Close()
+			t.Fatalf("CreateTagByPath returned error: %v", err)
+		}
+	}
+
+	return s, c
+}
+
+func TestExportProject(t *testing.T) {
+	tests := []struct {
+		name       string
+		opt        *kepserverex.ExportProjectOptions
+		status     int
+		serialized int
+	}{
+		{"serialize", nil, 0, 2},
+		{"crawl", &kepserverex.ExportProjectOptions{DisableSerialize: true, Concurrency: 1}, 0, 0},
+		{"fallback", nil, http.StatusBadRequest, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &serializeTransport{status: tt.status}
+			s, c := newProject(t, transport)
+			defer s.Close()
+
+			p, err := c.ExportProject(tt.opt)
+			if err != nil {
+				t.Fatalf("ExportProject returned error: %v", err)
+			}
+			if p.ProjectID != s.ProjectID() {
+				t.Errorf("ExportProject returned project ID %d, want %d", p.ProjectID, s.ProjectID())
+			}
+			if n := transport.count(); n != tt.serialized {
+				t.Errorf("ExportProject sent %d serialize requests, want %d", n, tt.serialized)
+			}
+
+			data, err := json.Marshal(p)
+			if err != nil {
+				t.Fatalf("Failed to marshal project: %v", err)
+			}
+			checkExport(t, data, s.ProjectID())
+		})
+	}
+}
+
+// checkExport checks the JSON output of an export of the project created
+// by newProject.
+func checkExport(t *testing.T, data []byte, projectID int64) {
+	var project struct {
+		ProjectID int64 `json:"PROJECT_ID"`
+		Channels  []struct {
+			Name    string `json:"common.ALLTYPES_NAME"`
+			Devices []struct {
+				Name      string            `json:"common.ALLTYPES_NAME"`
+				TagGroups []json.RawMessage `json:"tag_groups"`
+				Tags      []json.RawMessage `json:"tags"`
+			} `json:"devices"`
+		} `json:"channels"`
+	}
+	if err := json.Unmarshal(data, &project); err != nil {
+		t.Fatalf("Failed to unmarshal export: %v", err)
+	}
+
+	if project.ProjectID != projectID {
+		t.Errorf("export has project ID %d, want %d", project.ProjectID, projectID)
+	}
+	// Only the project itself may include the PROJECT_ID.
+	if n := bytes.Count(data, []byte(`"PROJECT_ID"`)); n != 1 {
+		t.Errorf("export contains %d PROJECT_ID properties, want 1", n)
+	}
+
+	if len(project.Channels) != 2 {
+		t.Fatalf("export contains %d channels, want 2", len(project.Channels))
+	}
+	for i, name := range []string{"Channel1", "Channel2"} {
+		ch := project.Channels[i]
+		if ch.Name != name {
+			t.Errorf("channel %d is %q, want %q", i, ch.Name, name)
+		}
+		if len(ch.Devices) != 1 || ch.Devices[0].Name != "Device1" {
+			t.Fatalf("channel %s contains %+v, want a single Device1", ch.Name, ch.Devices)
+		}
+	}
+
+	device := project.Channels[0].Devices[0]
+	if len(device.Tags) != 1 || !bytes.Contains(device.Tags[0], []byte(`"Tag1"`)) {
+		t.Errorf("device contains tags %s, want Tag1", device.Tags)
+	}
+	if len(device.TagGroups) != 1 {
+		t.Fatalf("device contains %d tag groups, want 1", len(device.TagGroups))
+	}
+
+	var group struct {
+		Name      string `json:"common.ALLTYPES_NAME"`
+		TagGroups []struct {
+			Name string            `json:"common.ALLTYPES_NAME"`
+			Tags []json.RawMessage `json:"tags"`
+		} `json:"tag_groups"`
+		Tags []json.RawMessage `json:"tags"`
+	}
+	if err := json.Unmarshal(device.TagGroups[0], &group); err != nil {
+		t.Fatalf("Failed to unmarshal tag group: %v", err)
+	}
+	if group.Name != "Group1" || len(group.Tags) != 1 || !bytes.Contains(group.Tags[0], []byte(`"Tag2"`)) {
+		t.Errorf("tag group is %s, want Group1 containing Tag2", device.TagGroups[0])
+	}
+	if len(group.TagGroups) != 1 || group.TagGroups[0].Name != "Group1" ||
+		len(group.TagGroups[0].Tags) != 1 || !bytes.Contains(group.TagGroups[0].Tags[0], []byte(`"Tag3"`)) {
+		t.Errorf("tag group is %s, want a nested Group1 containing Tag3", device.TagGroups[0])
+	}
+
+	// The second channel has an empty device.
+	if d := project.Channels[1].Devices[0]; len(d.TagGroups) != 0 || len(d.Tags) != 0 {
+		t.Errorf("device of Channel2 contains %d tag groups and %d tags, want none", len(d.TagGroups), len(d.Tags))
+	}
+}
+
+func TestExportProjectError(t *testing.T) {
+	s, _ := newProject(t, http.DefaultTransport)
+	defer s.Close()
+
+	c, err := kepserverex.NewClient(nil, "", s.Username, "wrong")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := c.SetBaseURL(s.URL); err != nil {
+		t.Fatalf("Failed to set base URL: %v", err)
+	}
+
+	if _, err := c.ExportProject(nil); !errors.Is(err, kepserverex.ErrUnauthorized) {
+		t.Fatalf("ExportProject returned error %v, want %v", err, kepserverex.ErrUnauthorized)
+	}
+}
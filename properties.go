@@ -0,0 +1,113 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+// Properties represents the raw properties of a KEPServerEX object, keyed
+// by their KEPServerEX property names (e.g. common.ALLTYPES_NAME).
+type Properties map[string]interface{}
+
+// Name returns the name of the object.
+func (p Properties) Name() string {
+	name, _ := p[nameProperty].(string)
+	return name
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Numbers are
+// decoded as json.Number values, so large IDs are not rounded.
+func (p *Properties) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil {
+		return err
+	}
+	*p = m
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (p Properties) MarshalYAML() (interface{}, error) {
+	return p.native(), nil
+}
+
+// native returns a copy of the properties with all JSON numbers converted
+// to native numbers, as YAML would otherwise marshal them as strings.
+func (p Properties) native() Properties {
+	m := make(Properties, len(p))
+	for k, v := range p {
+		if n, ok := v.(json.Number); ok {
+			if i, err := n.Int64(); err == nil {
+				v = i
+			} else if f, err := n.Float64(); err == nil {
+				v = f
+			}
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// GetString returns the value of the given property as a string, or an
+// empty string if the property is not set or not a string.
+func (p Properties) GetString(key string) string {
+	v, _ := p[key].(string)
+	return v
+}
+
+// GetInt64 returns the value of the given property as an int64, or zero
+// if the property is not set or not a number.
+func (p Properties) GetInt64(key string) int64 {
+	switch v := p[key].(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return int64(f)
+	case float64:
+		return int64(v)
+	case int:
+		return int64(v)
+	case int64:
+		return v
+	case string:
+		i, _ := strconv.ParseInt(v, 10, 64)
+		return i
+	}
+	return 0
+}
+
+// GetInt returns the value of the given property as an int, or zero if
+// the property is not set or not a number.
+func (p Properties) GetInt(key string) int {
+	return int(p.GetInt64(key))
+}
+
+// GetBool returns the value of the given property as a bool, or false if
+// the property is not set or not a bool.
+func (p Properties) GetBool(key string) bool {
+	v, _ := p[key].(bool)
+	return v
+}
@@ -0,0 +1,623 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const driverProperty = "servermain.MULTIPLE_TYPES_DEVICE_DRIVER"
+
+// DesiredProject represents the desired state of a project.
+//
+// A nil list of children means the children are not managed and are left
+// untouched, while a non-nil (but possibly empty) list means any existing
+// children that are not part of the list will be deleted.
+type DesiredProject struct {
+	Channels []*DesiredChannel
+}
+
+// DesiredChannel represents the desired state of a channel.
+type DesiredChannel struct {
+	Options *ChannelOptions
+	Devices []*DesiredDevice
+}
+
+// DesiredDevice represents the desired state of a device. The options can
+// be any of the available device options types (e.g. *OPCUAClientDeviceOptions)
+// or a GenericDevice.
+type DesiredDevice struct {
+	Options   interface{}
+	TagGroups []*DesiredTagGroup
+	Tags      []*TagOptions
+}
+
+// DesiredTagGroup represents the desired state of a tag group.
+type DesiredTagGroup struct {
+	Options   *TagGroupOptions
+	TagGroups []*DesiredTagGroup
+	Tags      []*TagOptions
+}
+
+// ObjectKind represents the kind of a project object.
+type ObjectKind int
+
+// List of available object kinds.
+const (
+	ObjectKind_Channel ObjectKind = iota
+	ObjectKind_Device
+	ObjectKind_TagGroup
+	ObjectKind_Tag
+)
+
+func (k ObjectKind) String() string {
+	switch k {
+	case ObjectKind_Channel:
+		return "channel"
+	case ObjectKind_Device:
+		return "device"
+	case ObjectKind_TagGroup:
+		return "tag group"
+	case ObjectKind_Tag:
+		return "tag"
+	}
+	return fmt.Sprintf("ObjectKind(%d)", int(k))
+}
+
+// PlanAction represents the action of a plan step.
+type PlanAction int
+
+// List of available plan actions.
+const (
+	PlanAction_Create PlanAction = iota
+	PlanAction_Update
+	PlanAction_Delete
+)
+
+func (a PlanAction) String() string {
+	switch a {
+	case PlanAction_Create:
+		return "create"
+	case PlanAction_Update:
+		return "update"
+	case PlanAction_Delete:
+		return "delete"
+	}
+	return fmt.Sprintf("PlanAction(%d)", int(a))
+}
+
+// PlanStep represents a single change to the project.
+type PlanStep struct {
+	Action PlanAction
+	Kind   ObjectKind
+
+	// Path is the dotted path of the object (e.g. Channel.Device.Group.Tag).
+	Path string
+
+	// Properties contains all properties set when creating the object or
+	// only the changed properties when updating the object.
+	Properties Properties
+
+	// Current contains the current values of the changed properties when
+	// updating the object.
+	Current Properties
+
+	channel string
+	device  string
+	tagPath *TagPath
+	name    string
+	opt     interface{}
+}
+
+// String returns a human readable representation of the step.
+func (s *PlanStep) String() string {
+	var b strings.Builder
+
+	switch s.Action {
+	case PlanAction_Create:
+		fmt.Fprintf(&b, "+ %s %s\n", s.Kind, s.Path)
+	case PlanAction_Update:
+		fmt.Fprintf(&b, "~ %s %s\n", s.Kind, s.Path)
+	case PlanAction_Delete:
+		fmt.Fprintf(&b, "- %s %s\n", s.Kind, s.Path)
+	}
+
+	keys := make([]string, 0, len(s.Properties))
+	for k := range s.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if s.Action == PlanAction_Update {
+			fmt.Fprintf(&b, "    %s: %s => %s\n", k, formatValue(s.Current[k]), formatValue(s.Properties[k]))
+		} else {
+			fmt.Fprintf(&b, "    %s: %s\n", k, formatValue(s.Properties[k]))
+		}
+	}
+
+	return b.String()
+}
+
+// Plan represents an ordered list of changes needed to bring the project
+// in the desired state.
+type Plan struct {
+	Steps []*PlanStep
+}
+
+// String returns a human readable representation of the plan.
+func (p *Plan) String() string {
+	var b strings.Builder
+	var create, update, remove int
+
+	for _, step := range p.Steps {
+		b.WriteString(step.String())
+		switch step.Action {
+		case PlanAction_Create:
+			create++
+		case PlanAction_Update:
+			update++
+		case PlanAction_Delete:
+			remove++
+		}
+	}
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to delete.\n", create, update, remove)
+
+	return b.String()
+}
+
+// PlanProjectOptions represents the available PlanProject() options.
+type PlanProjectOptions struct {
+	// SkipDelete prevents planning the deletion of objects that exist on
+	// the server but are not part of the desired state.
+	SkipDelete bool
+}
+
+// PlanProject compares the desired state with the current project and
+// returns the plan needed to bring the project in the desired state. Only
+// properties explicitly set in the options of the desired state are
+// compared, so properties that are not set keep their current values.
+func (c *Client) PlanProject(desired *DesiredProject, opt *PlanProjectOptions, options ...RequestOptionFunc) (*Plan, error) {
+	if opt == nil {
+		opt = &PlanProjectOptions{}
+	}
+
+	current, err := c.ExportProject(nil, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &planner{skipDelete: opt.SkipDelete}
+	if err := p.planChannels(desired.Channels, current.Channels); err != nil {
+		return nil, err
+	}
+
+	// Creates and updates are applied top-down, while deletes are applied
+	// bottom-up after all other changes.
+	sort.SliceStable(p.changes, func(i, j int) bool {
+		return p.changes[i].Kind < p.changes[j].Kind
+	})
+	sort.SliceStable(p.deletes, func(i, j int) bool {
+		return p.deletes[i].Kind > p.deletes[j].Kind
+	})
+
+	return &Plan{Steps: append(p.changes, p.deletes...)}, nil
+}
+
+// ApplyPlan applies all steps of the plan in order. It stops and returns
+// an error as soon as one of the steps fails.
+func (c *Client) ApplyPlan(plan *Plan, options ...RequestOptionFunc) error {
+	for _, step := range plan.Steps {
+		if err := c.applyStep(step, options); err != nil {
+			return fmt.Errorf("failed to %s %s %s: %v", step.Action, step.Kind, step.Path, err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) applyStep(step *PlanStep, options []RequestOptionFunc) error {
+	switch step.Kind {
+	case ObjectKind_Channel:
+		switch step.Action {
+		case PlanAction_Create:
+			return c.Channels.CreateChannel(step.opt.(*ChannelOptions), options...)
+		case PlanAction_Update:
+			u := fmt.Sprintf("channels/%s", url.PathEscape(step.name))
+			return c.updateProperties(u, step.Properties, options)
+		case PlanAction_Delete:
+			return c.Channels.DeleteChannel(step.name, options...)
+		}
+
+	case ObjectKind_Device:
+		switch step.Action {
+		case PlanAction_Create:
+			return c.Devices.createDevice(step.channel, step.opt, options...)
+		case PlanAction_Update:
+			u := fmt.Sprintf("channels/%s/devices/%s", url.PathEscape(step.channel), url.PathEscape(step.name))
+			return c.updateProperties(u, step.Properties, options)
+		case PlanAction_Delete:
+			return c.Devices.DeleteDevice(step.channel, step.name, options...)
+		}
+
+	case ObjectKind_TagGroup:
+		switch step.Action {
+		case PlanAction_Create:
+			return c.TagGroups.CreateTagGroupByPath(step.tagPath, step.opt.(*TagGroupOptions), options...)
+		case PlanAction_Update:
+			return c.updateProperties(step.tagPath.objectURL("tag_groups", step.name), step.Properties, options)
+		case PlanAction_Delete:
+			return c.TagGroups.DeleteTagGroupByPath(step.tagPath, step.name, options...)
+		}
+
+	case ObjectKind_Tag:
+		switch step.Action {
+		case PlanAction_Create:
+			return c.Tags.CreateTagByPath(step.tagPath, step.opt.(*TagOptions), options...)
+		case PlanAction_Update:
+			return c.updateProperties(step.tagPath.objectURL("tags", step.name), step.Properties, options)
+		case PlanAction_Delete:
+			return c.Tags.DeleteTagByPath(step.tagPath, step.name, options...)
+		}
+	}
+
+	return fmt.Errorf("unsupported plan step")
+}
+
+// updateProperties updates the object with the given path. Only the changed
+// properties are sent, so properties that were not explicitly set in the
+// desired state are never touched.
+func (c *Client) updateProperties(path string, props Properties, options []RequestOptionFunc) error {
+	req, err := c.NewRequest("PUT", path, props, options...)
+	if err != nil {
+		return err
+	}
+	return c.Do(req, nil)
+}
+
+// planner collects the steps of a plan.
+type planner struct {
+	skipDelete bool
+	changes    []*PlanStep
+	deletes    []*PlanStep
+}
+
+// plan compares the desired options with the current properties and adds
+// a create or update step when needed. It returns the name of the object.
+func (p *planner) plan(step *PlanStep, opt interface{}, current map[string]Properties) (string, error) {
+	desired, err := optionProperties(opt)
+	if err != nil {
+		return "", err
+	}
+	name := desired.Name()
+	if name == "" {
+		return "", fmt.Errorf("missing name for %s in %s", step.Kind, step.Path)
+	}
+
+	step.name = name
+	step.opt = opt
+	if step.Path == "" {
+		step.Path = name
+	} else {
+		step.Path += "." + name
+	}
+
+	props, ok := current[strings.ToLower(name)]
+	if !ok {
+		step.Action = PlanAction_Create
+		step.Properties = desired
+		p.changes = append(p.changes, step)
+		return name, nil
+	}
+	delete(current, strings.ToLower(name))
+
+	step.Properties = make(Properties)
+	step.Current = make(Properties)
+	for k, v := range desired {
+		switch {
+		case k == nameProperty:
+			continue
+		case k == driverProperty && v == "":
+			continue
+		}
+		if !equalValues(v, props[k]) {
+			step.Properties[k] = v
+			step.Current[k] = props[k]
+		}
+	}
+	if len(step.Properties) > 0 {
+		step.Action = PlanAction_Update
+		p.changes = append(p.changes, step)
+	}
+
+	return name, nil
+}
+
+// planDeletes adds delete steps for all remaining current objects.
+func (p *planner) planDeletes(template PlanStep, current map[string]Properties) {
+	if p.skipDelete {
+		return
+	}
+
+	names := make([]string, 0, len(current))
+	for _, props := range current {
+		names = append(names, props.Name())
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		step := template
+		step.Action = PlanAction_Delete
+		step.name = name
+		if step.Path == "" {
+			step.Path = name
+		} else {
+			step.Path += "." + name
+		}
+		p.deletes = append(p.deletes, &step)
+	}
+}
+
+func (p *planner) planChannels(desired []*DesiredChannel, current []*ProjectChannel) error {
+	if desired == nil {
+		return nil
+	}
+
+	channels := make(map[string]*ProjectChannel, len(current))
+	props := make(map[string]Properties, len(current))
+	for _, ch := range current {
+		channels[strings.ToLower(ch.Properties.Name())] = ch
+		props[strings.ToLower(ch.Properties.Name())] = ch.Properties
+	}
+
+	for _, d := range desired {
+		name, err := p.plan(&PlanStep{Kind: ObjectKind_Channel}, d.Options, props)
+		if err != nil {
+			return err
+		}
+
+		var devices []*ProjectDevice
+		if ch, ok := channels[strings.ToLower(name)]; ok {
+			devices = ch.Devices
+		}
+		if err := p.planDevices(name, d.Devices, devices); err != nil {
+			return err
+		}
+	}
+
+	p.planDeletes(PlanStep{Kind: ObjectKind_Channel}, props)
+
+	return nil
+}
+
+func (p *planner) planDevices(channel string, desired []*DesiredDevice, current []*ProjectDevice) error {
+	if desired == nil {
+		return nil
+	}
+
+	devices := make(map[string]*ProjectDevice, len(current))
+	props := make(map[string]Properties, len(current))
+	for _, d := range current {
+		devices[strings.ToLower(d.Properties.Name())] = d
+		props[strings.ToLower(d.Properties.Name())] = d.Properties
+	}
+
+	for _, d := range desired {
+		step := &PlanStep{Kind: ObjectKind_Device, Path: channel, channel: channel}
+		name, err := p.plan(step, d.Options, props)
+		if err != nil {
+			return err
+		}
+
+		var groups []*ProjectTagGroup
+		var tags []Properties
+		if dev, ok := devices[strings.ToLower(name)]; ok {
+			groups, tags = dev.TagGroups, dev.Tags
+		}
+		path := NewTagPath(channel, name)
+		if err := p.planTagGroups(path, d.TagGroups, groups); err != nil {
+			return err
+		}
+		if err := p.planTags(path, d.Tags, tags); err != nil {
+			return err
+		}
+	}
+
+	p.planDeletes(PlanStep{Kind: ObjectKind_Device, Path: channel, channel: channel}, props)
+
+	return nil
+}
+
+func (p *planner) planTagGroups(path *TagPath, desired []*DesiredTagGroup, current []*ProjectTagGroup) error {
+	if desired == nil {
+		return nil
+	}
+
+	groups := make(map[string]*ProjectTagGroup, len(current))
+	props := make(map[string]Properties, len(current))
+	for _, g := range current {
+		groups[strings.ToLower(g.Properties.Name())] = g
+		props[strings.ToLower(g.Properties.Name())] = g.Properties
+	}
+
+	for _, d := range desired {
+		step := &PlanStep{Kind: ObjectKind_TagGroup, Path: path.String(), tagPath: path}
+		name, err := p.plan(step, d.Options, props)
+		if err != nil {
+			return err
+		}
+
+		var subGroups []*ProjectTagGroup
+		var tags []Properties
+		if g, ok := groups[strings.ToLower(name)]; ok {
+			subGroups, tags = g.TagGroups, g.Tags
+		}
+		if err := p.planTagGroups(path.Child(name), d.TagGroups, subGroups); err != nil {
+			return err
+		}
+		if err := p.planTags(path.Child(name), d.Tags, tags); err != nil {
+			return err
+		}
+	}
+
+	p.planDeletes(PlanStep{Kind: ObjectKind_TagGroup, Path: path.String(), tagPath: path}, props)
+
+	return nil
+}
+
+func (p *planner) planTags(path *TagPath, desired []*TagOptions, current []Properties) error {
+	if desired == nil {
+		return nil
+	}
+
+	props := make(map[string]Properties, len(current))
+	for _, t := range current {
+		props[strings.ToLower(t.Name())] = t
+	}
+
+	for _, d := range desired {
+		step := &PlanStep{Kind: ObjectKind_Tag, Path: path.String(), tagPath: path}
+		if _, err := p.plan(step, d, props); err != nil {
+			return err
+		}
+	}
+
+	p.planDeletes(PlanStep{Kind: ObjectKind_Tag, Path: path.String(), tagPath: path}, props)
+
+	return nil
+}
+
+// optionProperties returns the properties explicitly set in the options.
+// A field is considered set when it is a non-nil pointer, or when it is not
+// a pointer and holds a non-zero value. Options that are not a struct (e.g.
+// a GenericDevice) are used as is.
+func optionProperties(opt interface{}) (Properties, error) {
+	v := reflect.ValueOf(opt)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return Properties{}, nil
+		}
+		v = v.Elem()
+	}
+
+	props := make(Properties)
+	if v.Kind() == reflect.Struct {
+		if err := structProperties(v, props); err != nil {
+			return nil, err
+		}
+	} else {
+		b, err := json.Marshal(opt)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &props); err != nil {
+			return nil, err
+		}
+	}
+	delete(props, projectIDProperty)
+
+	return props, nil
+}
+
+// structProperties adds the set fields of the given struct to props, using
+// their JSON names as property names. Embedded structs without a JSON name
+// (e.g. the ChannelOptions of a typed channel) are added recursively.
+func structProperties(v reflect.Value, props Properties) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+
+		tag := field.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			if value.Kind() == reflect.Ptr {
+				if value.IsNil() {
+					continue
+				}
+				value = value.Elem()
+			}
+			if value.Kind() == reflect.Struct {
+				if err := structProperties(value, props); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		switch value.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+			if value.IsNil() {
+				continue
+			}
+		default:
+			if value.IsZero() {
+				continue
+			}
+		}
+
+		// Round-trip the value, so it is represented like the properties
+		// decoded from the server.
+		b, err := json.Marshal(value.Interface())
+		if err != nil {
+			return err
+		}
+		var prop interface{}
+		if err := json.Unmarshal(b, &prop); err != nil {
+			return err
+		}
+		props[name] = prop
+	}
+
+	return nil
+}
+
+// equalValues compares two property values by their JSON representation.
+func equalValues(a, b interface{}) bool {
+	x, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	y, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(x, y)
+}
+
+func formatValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,187 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex_test
+
+import (
+	"testing"
+
+	kepserverex "github.com/svanharmelen/go-kepserverex"
+	"github.com/svanharmelen/go-kepserverex/kepserverextest"
+)
+
+func newReconcileClient(t *testing.T) (*kepserverextest.Server, *kepserverex.Client) {
+	s := kepserverextest.NewServer("user", "secret")
+	c, err := s.NewClient()
+	if err != nil {
+		s.Close()
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return s, c
+}
+
+// desiredProject returns a project with a single channel, device, tag group
+// and tag.
+func desiredProject() *kepserverex.DesiredProject {
+	return &kepserverex.DesiredProject{
+		Channels: []*kepserverex.DesiredChannel{{
+			Options: &kepserverex.ChannelOptions{
+				Name:   "Channel1",
+				Driver: kepserverex.ControlLogixEthernetDriver,
+			},
+			Devices: []*kepserverex.DesiredDevice{{
+				Options: &kepserverex.ControlLogixEthernetDeviceOptions{
+					Name:   "Device1",
+					Driver: kepserverex.ControlLogixEthernetDriver,
+					Model:  kepserverex.SoftLogix_5800,
+				},
+				TagGroups: []*kepserverex.DesiredTagGroup{{
+					Options: &kepserverex.TagGroupOptions{Name: "Group1"},
+					Tags: []*kepserverex.TagOptions{{
+						Name:    kepserverex.String("Tag1"),
+						Address: kepserverex.String("Program:Main.Tag1"),
+					}},
+				}},
+			}},
+		}},
+	}
+}
+
+func mustPlan(t *testing.T, c *kepserverex.Client, desired *kepserverex.DesiredProject, opt *kepserverex.PlanProjectOptions) *kepserverex.Plan {
+	plan, err := c.PlanProject(desired, opt)
+	if err != nil {
+		t.Fatalf("PlanProject returned error: %v", err)
+	}
+	return plan
+}
+
+func mustApply(t *testing.T, c *kepserverex.Client, plan *kepserverex.Plan) {
+	if err := c.ApplyPlan(plan); err != nil {
+		t.Fatalf("ApplyPlan returned error: %v", err)
+	}
+}
+
+func TestPlanProjectCreate(t *testing.T) {
+	s, c := newReconcileClient(t)
+	defer s.Close()
+
+	plan := mustPlan(t, c, desiredProject(), nil)
+
+	var paths []string
+	for _, step := range plan.Steps {
+		if step.Action != kepserverex.PlanAction_Create {
+			t.Errorf("Step %s has action %s, want create", step.Path, step.Action)
+		}
+		paths = append(paths, step.Path)
+	}
+	want := []string{"Channel1", "Channel1.Device1", "Channel1.Device1.Group1", "Channel1.Device1.Group1.Tag1"}
+	if len(paths) != len(want) {
+		t.Fatalf("Plan has steps %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("Step %d has path %q, want %q", i, paths[i], want[i])
+		}
+	}
+
+	mustApply(t, c, plan)
+
+	if plan := mustPlan(t, c, desiredProject(), nil); len(plan.Steps) != 0 {
+		t.Errorf("Plan after apply has steps:\n%s", plan)
+	}
+}
+
+func TestPlanProjectOnlySetProperties(t *testing.T) {
+	s, c := newReconcileClient(t)
+	defer s.Close()
+
+	mustApply(t, c, mustPlan(t, c, desiredProject(), nil))
+
+	// Leave the model and address unset, so they must not be touched.
+	desired := desiredProject()
+	device := desired.Channels[0].Devices[0]
+	device.Options = &kepserverex.ControlLogixEthernetDeviceOptions{
+		Name:        "Device1",
+		Description: "Updated",
+	}
+	device.TagGroups[0].Tags[0] = &kepserverex.TagOptions{
+		Name:        kepserverex.String("Tag1"),
+		Description: kepserverex.String("Updated"),
+	}
+
+	plan := mustPlan(t, c, desired, nil)
+	if len(plan.Steps) != 2 {
+		t.Fatalf("Plan has %d steps, want 2:\n%s", len(plan.Steps), plan)
+	}
+	for _, step := range plan.Steps {
+		if step.Action != kepserverex.PlanAction_Update {
+			t.Errorf("Step %s has action %s, want update", step.Path, step.Action)
+		}
+		if len(step.Properties) != 1 || step.Properties["common.ALLTYPES_DESCRIPTION"] != "Updated" {
+			t.Errorf("Step %s updates %v, want only the description", step.Path, step.Properties)
+		}
+	}
+
+	mustApply(t, c, plan)
+
+	d, err := c.Devices.GetControlLogixEthernetDevice("Channel1", "Device1")
+	if err != nil {
+		t.Fatalf("GetControlLogixEthernetDevice returned error: %v", err)
+	}
+	if d.Description != "Updated" || d.Model != kepserverex.SoftLogix_5800 {
+		t.Errorf("Device has description %q and model %v, want %q and %v",
+			d.Description, d.Model, "Updated", kepserverex.SoftLogix_5800)
+	}
+
+	tag, err := c.Tags.GetTag("Channel1", "Device1", "Group1", "Tag1")
+	if err != nil {
+		t.Fatalf("GetTag returned error: %v", err)
+	}
+	if tag.Description != "Updated" || tag.Address != "Program:Main.Tag1" {
+		t.Errorf("Tag has description %q and address %q, want %q and %q",
+			tag.Description, tag.Address, "Updated", "Program:Main.Tag1")
+	}
+}
+
+func TestPlanProjectDelete(t *testing.T) {
+	s, c := newReconcileClient(t)
+	defer s.Close()
+
+	mustApply(t, c, mustPlan(t, c, desiredProject(), nil))
+
+	desired := desiredProject()
+	desired.Channels[0].Devices[0].TagGroups[0].Tags = []*kepserverex.TagOptions{}
+
+	if plan := mustPlan(t, c, desired, &kepserverex.PlanProjectOptions{SkipDelete: true}); len(plan.Steps) != 0 {
+		t.Errorf("Plan with SkipDelete has steps:\n%s", plan)
+	}
+
+	plan := mustPlan(t, c, desired, nil)
+	if len(plan.Steps) != 1 || plan.Steps[0].Action != kepserverex.PlanAction_Delete ||
+		plan.Steps[0].Path != "Channel1.Device1.Group1.Tag1" {
+		t.Fatalf("Plan has steps:\n%s\nwant a single delete of Tag1", plan)
+	}
+
+	mustApply(t, c, plan)
+
+	tags, err := c.Tags.ListTags("Channel1", "Device1", "Group1")
+	if err != nil {
+		t.Fatalf("ListTags returned error: %v", err)
+	}
+	if len(tags) != 0 {
+		t.Errorf("ListTags returned %d tags, want none", len(tags))
+	}
+}
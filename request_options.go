@@ -0,0 +1,35 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"context"
+	"net/http"
+)
+
+// RequestOptionFunc can be passed to all API requests to customize the API request.
+type RequestOptionFunc func(*http.Request) error
+
+// WithContext runs the request with the provided context. Canceling the
+// context or exceeding its deadline aborts the request, including any
+// request that is already in-flight.
+func WithContext(ctx context.Context) RequestOptionFunc {
+	return func(req *http.Request) error {
+		*req = *req.WithContext(ctx)
+		return nil
+	}
+}
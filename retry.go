@@ -0,0 +1,224 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how requests failing with a transient error are
+// retried. KEPServerEX answers with a 503 or 429, or drops connections, while
+// the runtime is reinitializing.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts made for a request,
+	// including the first one. A value of 1 or less disables retries.
+	MaxAttempts int
+
+	// MinBackoff and MaxBackoff bound the exponential backoff between two
+	// attempts. They default to 500 milliseconds and 30 seconds.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// ShouldRetry reports whether a request should be retried after the
+	// given response or error. Defaults to DefaultShouldRetry.
+	ShouldRetry func(req *http.Request, resp *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy returns a retry policy suitable for most clients. Clients
+// do not retry requests unless a retry policy is set.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		ShouldRetry: DefaultShouldRetry,
+	}
+}
+
+// DefaultShouldRetry retries idempotent requests (GET, HEAD, OPTIONS, PUT and
+// DELETE) when the connection failed, or when the server answered with a 429,
+// 502, 503 or 504.
+func DefaultShouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+	default:
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// SetRetryPolicy sets the policy used to retry failed requests. A nil policy
+// disables retries, which is the default.
+func (c *Client) SetRetryPolicy(policy *RetryPolicy) {
+	c.retry = policy
+}
+
+// backoff returns the time to wait before the next attempt, after the given
+// number of attempts. A Retry-After header in the response is honored, as
+// long as it does not exceed the maximum backoff.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	min, max := p.MinBackoff, p.MaxBackoff
+	if min <= 0 {
+		min = 500 * time.Millisecond
+	}
+	if max < min {
+		max = 30 * time.Second
+		if max < min {
+			max = min
+		}
+	}
+
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > max {
+				wait = max
+			}
+			return wait
+		}
+	}
+
+	// Exponential backoff with jitter: a random duration between half
+	// and the full backoff for this attempt.
+	wait := max
+	if shift := uint(attempt - 1); shift < 32 {
+		if d := min << shift; d > 0 && d < max {
+			wait = d
+		}
+	}
+
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or a HTTP date.
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// send sends the request, retrying it according to the retry policy of the
+// client. Every attempt is subject to the limiter of the client and holds a
+// concurrency slot until its response body is closed, so no slot is held
+// while waiting to retry. Before every retry the request body is rewound
+// using GetBody.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	policy := c.retry
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.sendAttempt(req)
+
+		if policy == nil || attempt >= policy.MaxAttempts || req.Context().Err() != nil {
+			return resp, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+		shouldRetry := policy.ShouldRetry
+		if shouldRetry == nil {
+			shouldRetry = DefaultShouldRetry
+		}
+		if !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := policy.backoff(attempt, resp)
+		if resp != nil {
+			// Drain the body so the connection can be reused.
+			io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// sendAttempt makes a single attempt to send the request. The concurrency
+// slot taken from the limiter is released when the response body is closed.
+func (c *Client) sendAttempt(req *http.Request) (*http.Response, error) {
+	release, err := c.limiter.acquire(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.limiter.wait(req); err != nil {
+		release()
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+// releaseOnClose wraps a response body to release the concurrency slot of
+// the request when the body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
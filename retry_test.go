@@ -0,0 +1,324 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flaky returns a handler failing the first n requests with the given status
+// code, and a func returning the bodies of all received requests.
+func flaky(n, status int, header http.Header) (http.HandlerFunc, func() []string) {
+	var mu sync.Mutex
+	var bodies []string
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		attempt := len(bodies)
+		mu.Unlock()
+
+		if attempt <= n {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		switch r.Method {
+		case "POST":
+			w.WriteHeader(http.StatusCreated)
+		case "GET":
+			io.WriteString(w, `[]`)
+		}
+	}
+
+	received := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), bodies...)
+	}
+
+	return handler, received
+}
+
+func TestRetryDisabledByDefault(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	handler, received := flaky(1, http.StatusServiceUnavailable, nil)
+	mux.HandleFunc("/config/v1/project/channels", handler)
+
+	_, err := client.Channels.ListChannels()
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Code != http.StatusServiceUnavailable {
+		t.Fatalf("ListChannels returned error %v, want a 503 error", err)
+	}
+	if n := len(received()); n != 1 {
+		t.Fatalf("server received %d requests, want 1", n)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	handler, received := flaky(2, http.StatusServiceUnavailable, nil)
+	mux.HandleFunc("/config/v1/project/channels", handler)
+
+	client.SetRetryPolicy(&RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  20 * time.Millisecond,
+		MaxBackoff:  time.Second,
+	})
+
+	start := time.Now()
+	if _, err := client.Channels.ListChannels(); err != nil {
+		t.Fatalf("ListChannels returned error: %v", err)
+	}
+
+	if n := len(received()); n != 3 {
+		t.Fatalf("server received %d requests, want 3", n)
+	}
+	// The backoffs are at least 10ms and 20ms (half of 20ms and 40ms).
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("ListChannels took %v, want at least 30ms", elapsed)
+	}
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	handler, received := flaky(10, http.StatusTooManyRequests, nil)
+	mux.HandleFunc("/config/v1/project/channels", handler)
+
+	client.SetRetryPolicy(&RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+	})
+
+	_, err := client.Channels.ListChannels()
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Code != http.StatusTooManyRequests {
+		t.Fatalf("ListChannels returned error %v, want a 429 error", err)
+	}
+	if n := len(received()); n != 3 {
+		t.Fatalf("server received %d requests, want 3", n)
+	}
+}
+
+func TestRetryConnectionError(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	var mu sync.Mutex
+	attempts := 0
+
+	mux.HandleFunc("/config/v1/project/channels", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		attempt := attempts
+		mu.Unlock()
+
+		if attempt == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("failed to hijack connection: %v", err)
+				return
+			}
+			conn.Close()
+			return
+		}
+		io.WriteString(w, `[]`)
+	})
+
+	client.SetRetryPolicy(&RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+	})
+
+	if _, err := client.Channels.ListChannels(); err != nil {
+		t.Fatalf("ListChannels returned error: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != 2 {
+		t.Fatalf("server received %d requests, want 2", attempts)
+	}
+}
+
+func TestRetryGetBody(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	handler, received := flaky(1, http.StatusServiceUnavailable, nil)
+	mux.HandleFunc("/config/v1/project/channels/Channel1", handler)
+
+	client.SetRetryPolicy(&RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+	})
+
+	if err := client.Channels.UpdateChannel("Channel1", &ChannelOptions{Description: "Updated"}); err != nil {
+		t.Fatalf("UpdateChannel returned error: %v", err)
+	}
+
+	bodies := received()
+	if len(bodies) != 2 {
+		t.Fatalf("server received %d requests, want 2", len(bodies))
+	}
+	if bodies[0] == "" || bodies[1] != bodies[0] {
+		t.Fatalf("server received bodies %q, want the same body twice", bodies)
+	}
+}
+
+func TestRetryShouldRetry(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	handler, received := flaky(1, http.StatusServiceUnavailable, nil)
+	mux.HandleFunc("/config/v1/project/channels", handler)
+
+	policy := &RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+	}
+	client.SetRetryPolicy(policy)
+
+	// POST requests are not retried by default.
+	if err := client.Channels.CreateChannel(&ChannelOptions{Name: "Channel1"}); err == nil {
+		t.Fatal("CreateChannel returned no error, want a 503 error")
+	}
+	if n := len(received()); n != 1 {
+		t.Fatalf("server received %d requests, want 1", n)
+	}
+
+	policy.ShouldRetry = func(req *http.Request, resp *http.Response, err error) bool {
+		return err == nil && resp.StatusCode == http.StatusServiceUnavailable
+	}
+
+	handler, received = flaky(1, http.StatusServiceUnavailable, nil)
+	mux = http.NewServeMux()
+	mux.HandleFunc("/config/v1/project/channels", handler)
+	server.Config.Handler = mux
+
+	if err := client.Channels.CreateChannel(&ChannelOptions{Name: "Channel1"}); err != nil {
+		t.Fatalf("CreateChannel returned error: %v", err)
+	}
+	bodies := received()
+	if len(bodies) != 2 || bodies[1] != bodies[0] {
+		t.Fatalf("server received bodies %q, want the same body twice", bodies)
+	}
+}
+
+func TestRetryAfterHeader(t *testing.T) {
+	mux, server, client := setup(t)
+	defer teardown(server)
+
+	handler, received := flaky(1, http.StatusServiceUnavailable, http.Header{"Retry-After": {"1"}})
+	mux.HandleFunc("/config/v1/project/channels", handler)
+
+	client.SetRetryPolicy(&RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  2 * time.Second,
+	})
+
+	start := time.Now()
+	if _, err := client.Channels.ListChannels(); err != nil {
+		t.Fatalf("ListChannels returned error: %v", err)
+	}
+
+	if n := len(received()); n != 2 {
+		t.Fatalf("server received %d requests, want 2", n)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("ListChannels took %v, want at least 1s", elapsed)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		min   time.Duration
+		max   time.Duration
+		ok    bool
+	}{
+		{"", 0, 0, false},
+		{"0", 0, 0, true},
+		{"120", 120 * time.Second, 120 * time.Second, true},
+		{"-1", 0, 0, false},
+		{"soon", 0, 0, false},
+		{time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), 58 * time.Second, time.Minute, true},
+		{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		wait, ok := retryAfter(tt.value)
+		if ok != tt.ok || wait < tt.min || wait > tt.max {
+			t.Errorf("retryAfter(%q) returned %v, %t, want between %v and %v, %t",
+				tt.value, wait, ok, tt.min, tt.max, tt.ok)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{40, time.Second},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 100; i++ {
+			wait := p.backoff(tt.attempt, nil)
+			if wait < tt.max/2 || wait > tt.max {
+				t.Fatalf("backoff(%d) returned %v, want between %v and %v",
+					tt.attempt, wait, tt.max/2, tt.max)
+			}
+		}
+	}
+
+	// A Retry-After header is capped at the maximum backoff.
+	resp := &http.Response{Header: http.Header{"Retry-After": {"60"}}}
+	if wait := p.backoff(1, resp); wait != time.Second {
+		t.Errorf("backoff returned %v, want %v", wait, time.Second)
+	}
+}
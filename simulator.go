@@ -0,0 +1,93 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SimulatorRampTag returns the options for a Simulator tag that ramps from
+// low to high in steps of increment, updating every rate milliseconds.
+func SimulatorRampTag(name string, rate int, low, high, increment float64) *TagOptions {
+	dataType := DataType_Long
+	if !isWhole(low) || !isWhole(high) || !isWhole(increment) {
+		dataType = DataType_Float
+	}
+	return simulatorTag(name, simulatorFunc("RAMP", rate, formatFloat(low), formatFloat(high), formatFloat(increment)), dataType)
+}
+
+// SimulatorRandomTag returns the options for a Simulator tag that gets a
+// random value between low and high every rate milliseconds.
+func SimulatorRandomTag(name string, rate, low, high int) *TagOptions {
+	return simulatorTag(name, simulatorFunc("RANDOM", rate, strconv.Itoa(low), strconv.Itoa(high)), DataType_Long)
+}
+
+// SimulatorSineTag returns the options for a Simulator tag that follows a
+// sine wave between low and high, updating every rate milliseconds. The
+// frequency is in Hz and the phase in degrees.
+func SimulatorSineTag(name string, rate int, low, high, frequency, phase float64) *TagOptions {
+	return simulatorTag(name, simulatorFunc("SINE", rate, formatFloat(low), formatFloat(high), formatFloat(frequency), formatFloat(phase)), DataType_Float)
+}
+
+// SimulatorUserTag returns the options for a Simulator tag that cycles
+// through the given values, moving to the next value every rate milliseconds.
+func SimulatorUserTag(name string, rate int, values ...string) *TagOptions {
+	return simulatorTag(name, simulatorFunc("USER", rate, values...), DataType_String)
+}
+
+// SimulatorRegisterTag returns the options for a Simulator tag that reads
+// and writes the register at the given offset of a device using the given
+// model. Constant and register tags get the data type matching the register
+// size of the model: Byte for the 8-bit model and Word for the 16-bit model.
+func SimulatorRegisterTag(name string, model SimulatorModel, register SimulatorRegister, offset int) *TagOptions {
+	var dataType DataType
+	switch register {
+	case SimulatorRegister_Boolean:
+		dataType = DataType_Boolean
+	case SimulatorRegister_String:
+		dataType = DataType_String
+	default:
+		dataType = DataType_Word
+		if model == Simulator_8Bit {
+			dataType = DataType_Byte
+		}
+	}
+	return simulatorTag(name, fmt.Sprintf("%s%04d", register, offset), dataType)
+}
+
+func simulatorTag(name, address string, dataType DataType) *TagOptions {
+	return &TagOptions{
+		Name:     String(name),
+		Address:  String(address),
+		DataType: &dataType,
+	}
+}
+
+func simulatorFunc(function string, rate int, args ...string) string {
+	return fmt.Sprintf("%s (%s)", function, strings.Join(append([]string{strconv.Itoa(rate)}, args...), ", "))
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func isWhole(f float64) bool {
+	return f == math.Trunc(f)
+}
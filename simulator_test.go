@@ -0,0 +1,67 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import "testing"
+
+func TestSimulatorRegisterTag(t *testing.T) {
+	tests := []struct {
+		model    SimulatorModel
+		register SimulatorRegister
+		offset   int
+		address  string
+		dataType DataType
+	}{
+		{Simulator_16Bit, SimulatorRegister_Constant, 1, "K0001", DataType_Word},
+		{Simulator_16Bit, SimulatorRegister_Register, 100, "R0100", DataType_Word},
+		{Simulator_16Bit, SimulatorRegister_Boolean, 2, "B0002", DataType_Boolean},
+		{Simulator_16Bit, SimulatorRegister_String, 3, "S0003", DataType_String},
+		{Simulator_8Bit, SimulatorRegister_Constant, 1, "K0001", DataType_Byte},
+		{Simulator_8Bit, SimulatorRegister_Register, 100, "R0100", DataType_Byte},
+		{Simulator_8Bit, SimulatorRegister_Boolean, 2, "B0002", DataType_Boolean},
+		{Simulator_8Bit, SimulatorRegister_String, 3, "S0003", DataType_String},
+	}
+
+	for _, tt := range tests {
+		opt := SimulatorRegisterTag("Tag1", tt.model, tt.register, tt.offset)
+		if *opt.Name != "Tag1" || *opt.Address != tt.address || *opt.DataType != tt.dataType {
+			t.Errorf("SimulatorRegisterTag(%d, %s, %d) returned address %s and data type %d, want %s and %d",
+				tt.model, tt.register, tt.offset, *opt.Address, *opt.DataType, tt.address, tt.dataType)
+		}
+	}
+}
+
+func TestSimulatorFunctionTags(t *testing.T) {
+	tests := []struct {
+		opt      *TagOptions
+		address  string
+		dataType DataType
+	}{
+		{SimulatorRampTag("Tag1", 100, 0, 100, 1), "RAMP (100, 0, 100, 1)", DataType_Long},
+		{SimulatorRampTag("Tag1", 100, 0, 1, 0.1), "RAMP (100, 0, 1, 0.1)", DataType_Float},
+		{SimulatorRandomTag("Tag1", 250, -10, 10), "RANDOM (250, -10, 10)", DataType_Long},
+		{SimulatorSineTag("Tag1", 50, -1, 1, 0.5, 90), "SINE (50, -1, 1, 0.5, 90)", DataType_Float},
+		{SimulatorUserTag("Tag1", 1000, "Red", "Green"), "USER (1000, Red, Green)", DataType_String},
+	}
+
+	for _, tt := range tests {
+		if *tt.opt.Address != tt.address || *tt.opt.DataType != tt.dataType {
+			t.Errorf("got address %q and data type %d, want %q and %d",
+				*tt.opt.Address, *tt.opt.DataType, tt.address, tt.dataType)
+		}
+	}
+}
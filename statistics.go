@@ -0,0 +1,254 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"context"
+	"time"
+)
+
+// Statistics represents the runtime communication statistics of a channel
+// or device.
+type Statistics struct {
+	SuccessfulReads  int64 `json:"_SuccessfulReads"`
+	SuccessfulWrites int64 `json:"_SuccessfulWrites"`
+	FailedReads      int64 `json:"_FailedReads"`
+	FailedWrites     int64 `json:"_FailedWrites"`
+	Timeouts         int64 `json:"_Timeouts"`
+	RxBytes          int64 `json:"_RxBytes"`
+	TxBytes          int64 `json:"_TxBytes"`
+	PendingReads     int64 `json:"_PendingReads"`
+	PendingWrites    int64 `json:"_PendingWrites"`
+	MaxPendingReads  int64 `json:"_MaxPendingReads"`
+	MaxPendingWrites int64 `json:"_MaxPendingWrites"`
+}
+
+// Sub returns the difference between the statistics and the given previous
+// statistics. When a counter is lower than before (e.g. because the runtime
+// was restarted), the counter itself is used as the difference.
+func (s *Statistics) Sub(prev *Statistics) *Statistics {
+	counter := func(cur, prev int64) int64 {
+		if cur < prev {
+			return cur
+		}
+		return cur - prev
+	}
+	return &Statistics{
+		SuccessfulReads:  counter(s.SuccessfulReads, prev.SuccessfulReads),
+		SuccessfulWrites: counter(s.SuccessfulWrites, prev.SuccessfulWrites),
+		FailedReads:      counter(s.FailedReads, prev.FailedReads),
+		FailedWrites:     counter(s.FailedWrites, prev.FailedWrites),
+		Timeouts:         counter(s.Timeouts, prev.Timeouts),
+		RxBytes:          counter(s.RxBytes, prev.RxBytes),
+		TxBytes:          counter(s.TxBytes, prev.TxBytes),
+		PendingReads:     s.PendingReads - prev.PendingReads,
+		PendingWrites:    s.PendingWrites - prev.PendingWrites,
+		MaxPendingReads:  s.MaxPendingReads - prev.MaxPendingReads,
+		MaxPendingWrites: s.MaxPendingWrites - prev.MaxPendingWrites,
+	}
+}
+
+// Failures returns the total number of failed reads, failed writes and
+// timeouts.
+func (s *Statistics) Failures() int64 {
+	return s.FailedReads + s.FailedWrites + s.Timeouts
+}
+
+// DeviceStatus represents the runtime status of a device.
+type DeviceStatus struct {
+	Error                   bool `json:"_Error"`
+	Simulated               bool `json:"_Simulated"`
+	DataCollection          bool `json:"_Enabled"`
+	AutoDemoted             bool `json:"_AutoDemoted"`
+	AutoDemotedFailureCount int  `json:"_AutoDemotedFailureCount"`
+}
+
+// PollStatisticsOptions represents the available PollStatistics options.
+type PollStatisticsOptions struct {
+	// Interval between two polls. Defaults to 10 seconds.
+	Interval time.Duration
+
+	// Channels to poll. Defaults to all channels.
+	Channels []string
+
+	// Devices also polls the statistics and status of all devices within
+	// the polled channels.
+	Devices bool
+}
+
+// StatisticsDelta represents the change in the statistics of a channel, or
+// of a device when Device is set, since the previous poll.
+type StatisticsDelta struct {
+	Time    time.Time
+	Channel string
+	Device  string
+
+	// Statistics contains the current statistics and Delta the change
+	// since the previous poll.
+	Statistics *Statistics
+	Delta      *Statistics
+
+	// Status and PreviousStatus contain the current and previous status
+	// of a device. Both are nil for channels.
+	Status         *DeviceStatus
+	PreviousStatus *DeviceStatus
+
+	// Err is set when polling the channel or device failed.
+	Err error
+}
+
+// PollStatistics polls the runtime statistics of channels (and optionally
+// devices) until the context is done. The first poll only records the
+// current statistics. After that a delta is sent for every channel or
+// device whose statistics or status changed, and for every failed poll.
+// The returned channel is closed once the context is done.
+func (c *Client) PollStatistics(ctx context.Context, opt *PollStatisticsOptions, options ...RequestOptionFunc) <-chan *StatisticsDelta {
+	if opt == nil {
+		opt = new(PollStatisticsOptions)
+	}
+	interval := opt.Interval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	options = append(options[:len(options):len(options)], WithContext(ctx))
+
+	p := &statisticsPoller{
+		client:   c,
+		opt:      opt,
+		options:  options,
+		stats:    make(map[[2]string]*Statistics),
+		statuses: make(map[[2]string]*DeviceStatus),
+		deltas:   make(chan *StatisticsDelta),
+	}
+
+	go func() {
+		defer close(p.deltas)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			p.poll(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return p.deltas
+}
+
+// statisticsPoller keeps the state needed to compute statistics deltas.
+type statisticsPoller struct {
+	client  *Client
+	opt     *PollStatisticsOptions
+	options []RequestOptionFunc
+
+	stats    map[[2]string]*Statistics
+	statuses map[[2]string]*DeviceStatus
+	deltas   chan *StatisticsDelta
+}
+
+func (p *statisticsPoller) poll(ctx context.Context) {
+	channels := p.opt.Channels
+	if len(channels) == 0 {
+		list, err := p.client.Channels.ListChannels(p.options...)
+		if err != nil {
+			p.send(ctx, &StatisticsDelta{Err: err})
+			return
+		}
+		for _, ch := range list {
+			channels = append(channels, ch.Name)
+		}
+	}
+
+	for _, channel := range channels {
+		stats, err := p.client.Channels.GetChannelStatistics(channel, p.options...)
+		if !p.update(ctx, channel, "", stats, nil, err) {
+			return
+		}
+
+		if !p.opt.Devices {
+			continue
+		}
+
+		devices, err := p.client.Devices.ListDevices(channel, p.options...)
+		if err != nil {
+			if !p.send(ctx, &StatisticsDelta{Channel: channel, Err: err}) {
+				return
+			}
+			continue
+		}
+
+		for _, device := range devices {
+			stats, err := p.client.Devices.GetDeviceStatistics(channel, device.Name, p.options...)
+			var status *DeviceStatus
+			if err == nil {
+				status, err = p.client.Devices.GetDeviceStatus(channel, device.Name, p.options...)
+			}
+			if !p.update(ctx, channel, device.Name, stats, status, err) {
+				return
+			}
+		}
+	}
+}
+
+// update records the polled statistics and status and sends a delta when
+// anything changed. It returns false when the context is done.
+func (p *statisticsPoller) update(ctx context.Context, channel, device string, stats *Statistics, status *DeviceStatus, err error) bool {
+	if err != nil {
+		return p.send(ctx, &StatisticsDelta{Channel: channel, Device: device, Err: err})
+	}
+
+	key := [2]string{channel, device}
+	prevStats, ok := p.stats[key]
+	prevStatus := p.statuses[key]
+	p.stats[key] = stats
+	p.statuses[key] = status
+	if !ok {
+		return true
+	}
+
+	delta := stats.Sub(prevStats)
+	if *delta == (Statistics{}) && (status == nil || prevStatus == nil || *status == *prevStatus) {
+		return true
+	}
+
+	return p.send(ctx, &StatisticsDelta{
+		Channel:        channel,
+		Device:         device,
+		Statistics:     stats,
+		Delta:          delta,
+		Status:         status,
+		PreviousStatus: prevStatus,
+	})
+}
+
+func (p *statisticsPoller) send(ctx context.Context, delta *StatisticsDelta) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	delta.Time = time.Now()
+	select {
+	case p.deltas <- delta:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
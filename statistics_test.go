@@ -0,0 +1,167 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kepserverex "github.com/svanharmelen/go-kepserverex"
+	"github.com/svanharmelen/go-kepserverex/kepserverextest"
+)
+
+func TestStatisticsSub(t *testing.T) {
+	tests := []struct {
+		name string
+		cur  kepserverex.Statistics
+		prev kepserverex.Statistics
+		want kepserverex.Statistics
+	}{
+		{
+			name: "increased counters",
+			cur:  kepserverex.Statistics{SuccessfulReads: 150, FailedReads: 3, Timeouts: 1, RxBytes: 4096},
+			prev: kepserverex.Statistics{SuccessfulReads: 100, FailedReads: 1, RxBytes: 1024},
+			want: kepserverex.Statistics{SuccessfulReads: 50, FailedReads: 2, Timeouts: 1, RxBytes: 3072},
+		},
+		{
+			name: "counter reset",
+			cur:  kepserverex.Statistics{SuccessfulReads: 10, FailedWrites: 2, TxBytes: 512},
+			prev: kepserverex.Statistics{SuccessfulReads: 1000, FailedWrites: 5, TxBytes: 8192},
+			want: kepserverex.Statistics{SuccessfulReads: 10, FailedWrites: 2, TxBytes: 512},
+		},
+		{
+			name: "pending requests",
+			cur:  kepserverex.Statistics{PendingReads: 1, PendingWrites: 4, MaxPendingReads: 8},
+			prev: kepserverex.Statistics{PendingReads: 3, PendingWrites: 2, MaxPendingReads: 8},
+			want: kepserverex.Statistics{PendingReads: -2, PendingWrites: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.cur.Sub(&tt.prev); *got != tt.want {
+			t.Errorf("%s: Sub returned %+v, want %+v", tt.name, *got, tt.want)
+		}
+	}
+}
+
+// pollRound receives the deltas of a single poll. Polls end with the given
+// number of errors for the missing channel, which is polled last.
+func pollRound(t *testing.T, deltas <-chan *kepserverex.StatisticsDelta, errs int) []*kepserverex.StatisticsDelta {
+	var round []*kepserverex.StatisticsDelta
+	for errs > 0 {
+		select {
+		case d, ok := <-deltas:
+			if !ok {
+				t.Fatal("deltas channel closed")
+			}
+			if d.Channel == "Missing" {
+				if !errors.Is(d.Err, kepserverex.ErrNotFound) {
+					t.Fatalf("delta for the missing channel has error %v, want %v", d.Err, kepserverex.ErrNotFound)
+				}
+				errs--
+				continue
+			}
+			if d.Err != nil {
+				t.Fatalf("delta for %s/%s has error %v", d.Channel, d.Device, d.Err)
+			}
+			round = append(round, d)
+		case <-time.After(time.Second):
+			t.Fatal("received no delta")
+		}
+	}
+	return round
+}
+
+func TestPollStatistics(t *testing.T) {
+	s := kepserverextest.NewServer("user", "secret")
+	defer s.Close()
+
+	c, err := s.NewClient()
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if err := c.Channels.CreateChannel(&kepserverex.ChannelOptions{Name: "Channel1", Driver: kepserverex.SimulatorDriver}); err != nil {
+		t.Fatalf("CreateChannel returned error: %v", err)
+	}
+	if err := c.Devices.CreateSimulatorDevice("Channel1", &kepserverex.SimulatorDeviceOptions{Name: "Device1"}); err != nil {
+		t.Fatalf("CreateSimulatorDevice returned error: %v", err)
+	}
+
+	s.SetStatistics("Channel1", "", kepserverex.Statistics{SuccessfulReads: 100})
+	s.SetStatistics("Channel1", "Device1", kepserverex.Statistics{SuccessfulReads: 100, FailedReads: 5})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deltas := c.PollStatistics(ctx, &kepserverex.PollStatisticsOptions{
+		Interval: 10 * time.Millisecond,
+		Channels: []string{"Channel1", "Missing"},
+		Devices:  true,
+	})
+
+	// The first poll only records the statistics, and polls without any
+	// changes send no deltas.
+	for i := 0; i < 2; i++ {
+		if round := pollRound(t, deltas, 2); len(round) != 0 {
+			t.Fatalf("poll %d sent %d deltas, want none", i, len(round))
+		}
+	}
+
+	s.SetStatistics("Channel1", "", kepserverex.Statistics{SuccessfulReads: 120, FailedReads: 2})
+	round := pollRound(t, deltas, 2)
+	if len(round) != 1 || round[0].Device != "" {
+		t.Fatalf("poll sent %d deltas, want a single channel delta", len(round))
+	}
+	if want := (kepserverex.Statistics{SuccessfulReads: 20, FailedReads: 2}); *round[0].Delta != want {
+		t.Errorf("channel delta is %+v, want %+v", *round[0].Delta, want)
+	}
+	if round[0].Statistics.SuccessfulReads != 120 {
+		t.Errorf("channel statistics are %+v, want the current statistics", *round[0].Statistics)
+	}
+
+	// The runtime was restarted, resetting the counters of the device.
+	s.SetStatistics("Channel1", "Device1", kepserverex.Statistics{SuccessfulReads: 7, FailedReads: 1})
+	round = pollRound(t, deltas, 2)
+	if len(round) != 1 || round[0].Device != "Device1" {
+		t.Fatalf("poll sent %d deltas, want a single device delta", len(round))
+	}
+	if want := (kepserverex.Statistics{SuccessfulReads: 7, FailedReads: 1}); *round[0].Delta != want {
+		t.Errorf("device delta is %+v, want %+v", *round[0].Delta, want)
+	}
+
+	status := *round[0].Status
+	status.Error = true
+	s.SetDeviceStatus("Channel1", "Device1", status)
+	round = pollRound(t, deltas, 2)
+	if len(round) != 1 || round[0].Device != "Device1" {
+		t.Fatalf("poll sent %d deltas, want a single device delta", len(round))
+	}
+	if !round[0].Status.Error || round[0].PreviousStatus.Error {
+		t.Errorf("device delta has status %+v and previous status %+v, want the error to be set",
+			*round[0].Status, *round[0].PreviousStatus)
+	}
+	if *round[0].Delta != (kepserverex.Statistics{}) {
+		t.Errorf("device delta is %+v, want no change", *round[0].Delta)
+	}
+
+	cancel()
+	for range deltas {
+	}
+}
@@ -16,11 +16,6 @@
 
 package kepserverex
 
-import (
-	"fmt"
-	"net/url"
-)
-
 // TagGroupService handles communication with the tag group related
 // methods of the KEPServerEX API.
 type TagGroupService struct {
@@ -31,6 +26,7 @@ type TagGroupService struct {
 type TagGroup struct {
 	Name          string `json:"common.ALLTYPES_NAME"`
 	Description   string `json:"common.ALLTYPES_DESCRIPTION"`
+	ProjectID     int64  `json:"PROJECT_ID"`
 	LocalTagCount int    `json:"servermain.TAGGROUP_LOCAL_TAG_COUNT"`
 	TotalTagCount int    `json:"servermain.TAGGROUP_TOTAL_TAG_COUNT"`
 	AutoGenerated bool   `json:"servermain.TAGGROUP_AUTOGENERATED"`
@@ -38,16 +34,20 @@ type TagGroup struct {
 
 // TagGroupOptions represents all tag group options.
 type TagGroupOptions struct {
-	Name          string `json:"common.ALLTYPES_NAME"`
-	Description   string `json:"common.ALLTYPES_DESCRIPTION"`
+	Name          string `json:"common.ALLTYPES_NAME,omitempty"`
+	Description   string `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID     int64  `json:"PROJECT_ID,omitempty"`
 	AutoGenerated bool   `json:"servermain.TAGGROUP_AUTOGENERATED"`
 }
 
 // ListTagGroups gets a list of tag groups.
-func (s *TagGroupService) ListTagGroups(channel, device string) ([]*TagGroup, error) {
-	u := fmt.Sprintf("channels/%s/devices/%s/tag_groups", url.PathEscape(channel), url.PathEscape(device))
+func (s *TagGroupService) ListTagGroups(channel, device string, options ...RequestOptionFunc) ([]*TagGroup, error) {
+	return s.ListTagGroupsByPath(NewTagPath(channel, device), options...)
+}
 
-	req, err := s.client.NewRequest("GET", u, nil)
+// ListTagGroupsByPath gets a list of tag groups within the given path.
+func (s *TagGroupService) ListTagGroupsByPath(path *TagPath, options ...RequestOptionFunc) ([]*TagGroup, error) {
+	req, err := s.client.NewRequest("GET", path.collectionURL("tag_groups"), nil, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -61,29 +61,48 @@ func (s *TagGroupService) ListTagGroups(channel, device string) ([]*TagGroup, er
 }
 
 // CreateTagGroup creates a new tag group.
-func (s *TagGroupService) CreatetagGroup(channel, device string, options *TagGroupOptions) error {
-	u := fmt.Sprintf("channels/%s/devices/%s/tag_groups", url.PathEscape(channel), url.PathEscape(device))
-	req, err := s.client.NewRequest("POST", u, options)
+func (s *TagGroupService) CreatetagGroup(channel, device string, opt *TagGroupOptions, options ...RequestOptionFunc) error {
+	return s.CreateTagGroupByPath(NewTagPath(channel, device), opt, options...)
+}
+
+// CreateTagGroupByPath creates a new tag group within the given path.
+func (s *TagGroupService) CreateTagGroupByPath(path *TagPath, opt *TagGroupOptions, options ...RequestOptionFunc) error {
+	req, err := s.client.NewRequest("POST", path.collectionURL("tag_groups"), opt, options...)
 	if err != nil {
 		return err
 	}
 	return s.client.Do(req, nil)
 }
 
+// CreateTagGroups creates multiple tag groups at once. Large inputs are
+// created in chunks, see Client.SetBulkChunkSize. A result is returned for
+// every tag group which creation was attempted.
+func (s *TagGroupService) CreateTagGroups(channel, device string, opts []*TagGroupOptions, options ...RequestOptionFunc) ([]*BulkResult, error) {
+	return s.CreateTagGroupsByPath(NewTagPath(channel, device), opts, options...)
+}
+
+// CreateTagGroupsByPath creates multiple tag groups at once within the given path.
+func (s *TagGroupService) CreateTagGroupsByPath(path *TagPath, opts []*TagGroupOptions, options ...RequestOptionFunc) ([]*BulkResult, error) {
+	tagGroups := make([]interface{}, len(opts))
+	for i, opt := range opts {
+		tagGroups[i] = opt
+	}
+	return s.client.bulkCreate(path.collectionURL("tag_groups"), tagGroups, nil, options)
+}
+
 // GetTagGroup gets a specific tag group.
-func (s *TagGroupService) GetTagGroup(channel, device, name string) (*TagGroup, error) {
-	u := fmt.Sprintf("channels/%s/devices/%s/tag_groups/%s",
-		url.PathEscape(channel),
-		url.PathEscape(device),
-		url.PathEscape(name),
-	)
-
-	req, err := s.client.NewRequest("GET", u, nil)
+func (s *TagGroupService) GetTagGroup(channel, device, name string, options ...RequestOptionFunc) (*TagGroup, error) {
+	return s.GetTagGroupByPath(NewTagPath(channel, device), name, options...)
+}
+
+// GetTagGroupByPath gets a specific tag group within the given path.
+func (s *TagGroupService) GetTagGroupByPath(path *TagPath, name string, options ...RequestOptionFunc) (*TagGroup, error) {
+	req, err := s.client.NewRequest("GET", path.objectURL("tag_groups", name), nil, options...)
 	if err != nil {
 		return nil, err
 	}
 
-	var tagGroup *TagGroup
+	tagGroup := new(TagGroup)
 	if err = s.client.Do(req, tagGroup); err != nil {
 		return nil, err
 	}
@@ -92,13 +111,13 @@ func (s *TagGroupService) GetTagGroup(channel, device, name string) (*TagGroup,
 }
 
 // UpdateTagGroup updates an existing tag group.
-func (s *TagGroupService) UpdateTagGroup(channel, device, name string, options *TagGroupOptions) error {
-	u := fmt.Sprintf("channels/%s/devices/%s/tag_groups/%s",
-		url.PathEscape(channel),
-		url.PathEscape(device),
-		url.PathEscape(name),
-	)
-	req, err := s.client.NewRequest("PUT", u, options)
+func (s *TagGroupService) UpdateTagGroup(channel, device, name string, opt *TagGroupOptions, options ...RequestOptionFunc) error {
+	return s.UpdateTagGroupByPath(NewTagPath(channel, device), name, opt, options...)
+}
+
+// UpdateTagGroupByPath updates an existing tag group within the given path.
+func (s *TagGroupService) UpdateTagGroupByPath(path *TagPath, name string, opt *TagGroupOptions, options ...RequestOptionFunc) error {
+	req, err := s.client.NewRequest("PUT", path.objectURL("tag_groups", name), opt, options...)
 	if err != nil {
 		return err
 	}
@@ -106,13 +125,13 @@ func (s *TagGroupService) UpdateTagGroup(channel, device, name string, options *
 }
 
 // DeleteTagGroup deletes a tag group.
-func (s *TagGroupService) DeleteTagGroup(channel, device, name string) error {
-	u := fmt.Sprintf("channels/%s/devices/%s/tag_groups/%s",
-		url.PathEscape(channel),
-		url.PathEscape(device),
-		url.PathEscape(name),
-	)
-	req, err := s.client.NewRequest("DELETE", u, nil)
+func (s *TagGroupService) DeleteTagGroup(channel, device, name string, options ...RequestOptionFunc) error {
+	return s.DeleteTagGroupByPath(NewTagPath(channel, device), name, options...)
+}
+
+// DeleteTagGroupByPath deletes a tag group within the given path.
+func (s *TagGroupService) DeleteTagGroupByPath(path *TagPath, name string, options ...RequestOptionFunc) error {
+	req, err := s.client.NewRequest("DELETE", path.objectURL("tag_groups", name), nil, options...)
 	if err != nil {
 		return err
 	}
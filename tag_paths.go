@@ -0,0 +1,104 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// TagPath represents the location of a (nested) tag group within a device.
+// A path without any groups refers to the root of the device itself.
+type TagPath struct {
+	Channel string
+	Device  string
+	Groups  []string
+}
+
+// NewTagPath returns a new tag path for the given channel, device and
+// (optional) groups. Each group may itself be a dotted group path.
+func NewTagPath(channel, device string, groups ...string) *TagPath {
+	p := &TagPath{Channel: channel, Device: device}
+	for _, group := range groups {
+		if group != "" {
+			p.Groups = append(p.Groups, strings.Split(group, ".")...)
+		}
+	}
+	return p
+}
+
+// ParseTagPath parses a dotted tag path (e.g. Channel.Device.Group.SubGroup)
+// into a TagPath. The path should at least contain a channel and a device.
+func ParseTagPath(path string) (*TagPath, error) {
+	parts := strings.Split(path, ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid tag path %q: expected at least a channel and a device", path)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("invalid tag path %q: empty path element", path)
+		}
+	}
+	return &TagPath{Channel: parts[0], Device: parts[1], Groups: parts[2:]}, nil
+}
+
+// ParseTagName parses a fully qualified, dotted tag name (e.g.
+// Channel.Device.Group.SubGroup.Tag) into the path of the tag and the
+// name of the tag itself.
+func ParseTagName(name string) (*TagPath, string, error) {
+	i := strings.LastIndex(name, ".")
+	if i < 0 {
+		return nil, "", fmt.Errorf("invalid tag name %q: expected at least a channel and a device", name)
+	}
+	p, err := ParseTagPath(name[:i])
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid tag name %q: %v", name, err)
+	}
+	if name[i+1:] == "" {
+		return nil, "", fmt.Errorf("invalid tag name %q: empty tag name", name)
+	}
+	return p, name[i+1:], nil
+}
+
+// Child returns a new path pointing to the given group within this path.
+func (p *TagPath) Child(group string) *TagPath {
+	groups := make([]string, len(p.Groups), len(p.Groups)+1)
+	copy(groups, p.Groups)
+	return &TagPath{Channel: p.Channel, Device: p.Device, Groups: append(groups, group)}
+}
+
+// String returns the dotted representation of the path.
+func (p *TagPath) String() string {
+	return strings.Join(append([]string{p.Channel, p.Device}, p.Groups...), ".")
+}
+
+// collectionURL returns the relative URL of the given collection (either
+// tags or tag_groups) within the path.
+func (p *TagPath) collectionURL(collection string) string {
+	u := fmt.Sprintf("channels/%s/devices/%s", url.PathEscape(p.Channel), url.PathEscape(p.Device))
+	for _, group := range p.Groups {
+		u += "/tag_groups/" + url.PathEscape(group)
+	}
+	return u + "/" + collection
+}
+
+// objectURL returns the relative URL of the named object in the given
+// collection (either tags or tag_groups) within the path.
+func (p *TagPath) objectURL(collection, name string) string {
+	return p.collectionURL(collection) + "/" + url.PathEscape(name)
+}
@@ -0,0 +1,137 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTagPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    TagPath
+		wantErr bool
+	}{
+		{path: "Channel1.Device1", want: TagPath{Channel: "Channel1", Device: "Device1", Groups: []string{}}},
+		{path: "Channel1.Device1.Group1", want: TagPath{Channel: "Channel1", Device: "Device1", Groups: []string{"Group1"}}},
+		{path: "Channel1.Device1.Group1.Sub 1", want: TagPath{Channel: "Channel1", Device: "Device1", Groups: []string{"Group1", "Sub 1"}}},
+		{path: "", wantErr: true},
+		{path: "Channel1", wantErr: true},
+		{path: "Channel1.", wantErr: true},
+		{path: ".Device1", wantErr: true},
+		{path: "Channel1.Device1..Group1", wantErr: true},
+		{path: "Channel1.Device1.Group1.", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTagPath(tt.path)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseTagPath(%q) returned %+v, want an error", tt.path, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseTagPath(%q) returned error: %v", tt.path, err)
+			continue
+		}
+		if !reflect.DeepEqual(*got, tt.want) {
+			t.Errorf("ParseTagPath(%q) returned %+v, want %+v", tt.path, *got, tt.want)
+		}
+		if s := got.String(); s != tt.path {
+			t.Errorf("String returned %q, want %q", s, tt.path)
+		}
+	}
+}
+
+func TestParseTagName(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantPath string
+		wantTag  string
+		wantErr  bool
+	}{
+		{name: "Channel1.Device1.Tag1", wantPath: "Channel1.Device1", wantTag: "Tag1"},
+		{name: "Channel1.Device1.Group1.Sub1.Tag1", wantPath: "Channel1.Device1.Group1.Sub1", wantTag: "Tag1"},
+		{name: "Tag1", wantErr: true},
+		{name: "Channel1.Tag1", wantErr: true},
+		{name: "Channel1.Device1.", wantErr: true},
+		{name: "Channel1..Tag1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		path, tag, err := ParseTagName(tt.name)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseTagName(%q) returned %v and %q, want an error", tt.name, path, tag)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseTagName(%q) returned error: %v", tt.name, err)
+			continue
+		}
+		if path.String() != tt.wantPath || tag != tt.wantTag {
+			t.Errorf("ParseTagName(%q) returned %v and %q, want %v and %q", tt.name, path, tag, tt.wantPath, tt.wantTag)
+		}
+	}
+}
+
+func TestNewTagPath(t *testing.T) {
+	p := NewTagPath("Channel1", "Device1", "", "Group1.Sub1", "Sub2")
+	want := []string{"Group1", "Sub1", "Sub2"}
+	if !reflect.DeepEqual(p.Groups, want) {
+		t.Errorf("NewTagPath returned groups %v, want %v", p.Groups, want)
+	}
+
+	// Child must not change the groups of the parent path.
+	parent := &TagPath{Channel: "Channel1", Device: "Device1", Groups: make([]string, 1, 4)}
+	parent.Groups[0] = "Group1"
+	a, b := parent.Child("A"), parent.Child("B")
+	if a.String() != "Channel1.Device1.Group1.A" || b.String() != "Channel1.Device1.Group1.B" {
+		t.Errorf("Child returned %v and %v, want separate paths", a, b)
+	}
+	if len(parent.Groups) != 1 {
+		t.Errorf("Child changed the parent path to %v", parent)
+	}
+}
+
+func TestTagPathURLs(t *testing.T) {
+	tests := []struct {
+		path       *TagPath
+		collection string
+		want       string
+	}{
+		{NewTagPath("Channel1", "Device1"), "tags", "channels/Channel1/devices/Device1/tags"},
+		{NewTagPath("Channel1", "Device1"), "tag_groups", "channels/Channel1/devices/Device1/tag_groups"},
+		{NewTagPath("Channel1", "Device1", "Group1.Sub1"), "tags", "channels/Channel1/devices/Device1/tag_groups/Group1/tag_groups/Sub1/tags"},
+		{NewTagPath("Channel1", "Device1", "Group1"), "tag_groups", "channels/Channel1/devices/Device1/tag_groups/Group1/tag_groups"},
+		{&TagPath{Channel: "Channel 1", Device: "Device/1", Groups: []string{"Group 1", "50%"}}, "tags", "channels/Channel%201/devices/Device%2F1/tag_groups/Group%201/tag_groups/50%25/tags"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.path.collectionURL(tt.collection); got != tt.want {
+			t.Errorf("collectionURL(%q) for %v returned %q, want %q", tt.collection, tt.path, got, tt.want)
+		}
+	}
+
+	p := NewTagPath("Channel1", "Device1", "Group1")
+	if got, want := p.objectURL("tags", "Tag 1"), "channels/Channel1/devices/Device1/tag_groups/Group1/tags/Tag%201"; got != want {
+		t.Errorf("objectURL returned %q, want %q", got, want)
+	}
+}
@@ -16,11 +16,6 @@
 
 package kepserverex
 
-import (
-	"fmt"
-	"net/url"
-)
-
 // TagService handles communication with the tag related methods
 // of the KEPServerEX API.
 type TagService struct {
@@ -53,6 +48,7 @@ type Tag struct {
 type TagOptions struct {
 	Name           *string         `json:"common.ALLTYPES_NAME,omitempty"`
 	Description    *string         `json:"common.ALLTYPES_DESCRIPTION,omitempty"`
+	ProjectID      int64           `json:"PROJECT_ID,omitempty"`
 	Address        *string         `json:"servermain.TAG_ADDRESS,omitempty"`
 	DataType       *DataType       `json:"servermain.TAG_DATA_TYPE,omitempty"`
 	ClientAccess   *ClientAccess   `json:"servermain.TAG_READ_WRITE_ACCESS,omitempty"`
@@ -70,15 +66,15 @@ type TagOptions struct {
 	Units          *string         `json:"servermain.TAG_SCALING_UNITS,omitempty"`
 }
 
-// ListTags gets a list of tags.
-func (s *TagService) ListTags(channel, device, group string) ([]*Tag, error) {
-	u := fmt.Sprintf("channels/%s/devices/%s/tag_groups/%s/tags",
-		url.PathEscape(channel),
-		url.PathEscape(device),
-		url.PathEscape(group),
-	)
+// ListTags gets a list of tags. The group may be a dotted path to a nested
+// tag group, or empty to list the tags directly under the device.
+func (s *TagService) ListTags(channel, device, group string, options ...RequestOptionFunc) ([]*Tag, error) {
+	return s.ListTagsByPath(NewTagPath(channel, device, group), options...)
+}
 
-	req, err := s.client.NewRequest("GET", u, nil)
+// ListTagsByPath gets a list of tags within the given path.
+func (s *TagService) ListTagsByPath(path *TagPath, options ...RequestOptionFunc) ([]*Tag, error) {
+	req, err := s.client.NewRequest("GET", path.collectionURL("tags"), nil, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -92,34 +88,49 @@ func (s *TagService) ListTags(channel, device, group string) ([]*Tag, error) {
 }
 
 // CreateTag creates a new tag.
-func (s *TagService) CreateTag(channel, device, group string, options *TagOptions) error {
-	u := fmt.Sprintf("channels/%s/devices/%s/tag_groups/%s/tags",
-		url.PathEscape(channel),
-		url.PathEscape(device),
-		url.PathEscape(group),
-	)
-	req, err := s.client.NewRequest("POST", u, options)
+func (s *TagService) CreateTag(channel, device, group string, opt *TagOptions, options ...RequestOptionFunc) error {
+	return s.CreateTagByPath(NewTagPath(channel, device, group), opt, options...)
+}
+
+// CreateTagByPath creates a new tag within the given path.
+func (s *TagService) CreateTagByPath(path *TagPath, opt *TagOptions, options ...RequestOptionFunc) error {
+	req, err := s.client.NewRequest("POST", path.collectionURL("tags"), opt, options...)
 	if err != nil {
 		return err
 	}
 	return s.client.Do(req, nil)
 }
 
+// CreateTags creates multiple tags at once. The group may be a dotted path
+// to a nested tag group, or empty to create the tags directly under the
+// device. Large inputs are created in chunks, see Client.SetBulkChunkSize.
+// A result is returned for every tag which creation was attempted.
+func (s *TagService) CreateTags(channel, device, group string, opts []*TagOptions, options ...RequestOptionFunc) ([]*BulkResult, error) {
+	return s.CreateTagsByPath(NewTagPath(channel, device, group), opts, options...)
+}
+
+// CreateTagsByPath creates multiple tags at once within the given path.
+func (s *TagService) CreateTagsByPath(path *TagPath, opts []*TagOptions, options ...RequestOptionFunc) ([]*BulkResult, error) {
+	tags := make([]interface{}, len(opts))
+	for i, opt := range opts {
+		tags[i] = opt
+	}
+	return s.client.bulkCreate(path.collectionURL("tags"), tags, nil, options)
+}
+
 // GetTag gets a specific tag.
-func (s *TagService) GetTag(channel, device, group, name string) (*Tag, error) {
-	u := fmt.Sprintf("channels/%s/devices/%s/tag_groups/%s/tags/%s",
-		url.PathEscape(channel),
-		url.PathEscape(device),
-		url.PathEscape(group),
-		url.PathEscape(name),
-	)
-
-	req, err := s.client.NewRequest("GET", u, nil)
+func (s *TagService) GetTag(channel, device, group, name string, options ...RequestOptionFunc) (*Tag, error) {
+	return s.GetTagByPath(NewTagPath(channel, device, group), name, options...)
+}
+
+// GetTagByPath gets a specific tag within the given path.
+func (s *TagService) GetTagByPath(path *TagPath, name string, options ...RequestOptionFunc) (*Tag, error) {
+	req, err := s.client.NewRequest("GET", path.objectURL("tags", name), nil, options...)
 	if err != nil {
 		return nil, err
 	}
 
-	var tag *Tag
+	tag := new(Tag)
 	if err = s.client.Do(req, tag); err != nil {
 		return nil, err
 	}
@@ -128,14 +139,13 @@ func (s *TagService) GetTag(channel, device, group, name string) (*Tag, error) {
 }
 
 // UpdateTag updates an existing tag.
-func (s *TagService) UpdateTag(channel, device, group, name string, options *TagOptions) error {
-	u := fmt.Sprintf("channels/%s/devices/%s/tag_groups/%s/tags/%s",
-		url.PathEscape(channel),
-		url.PathEscape(device),
-		url.PathEscape(group),
-		url.PathEscape(name),
-	)
-	req, err := s.client.NewRequest("PUT", u, options)
+func (s *TagService) UpdateTag(channel, device, group, name string, opt *TagOptions, options ...RequestOptionFunc) error {
+	return s.UpdateTagByPath(NewTagPath(channel, device, group), name, opt, options...)
+}
+
+// UpdateTagByPath updates an existing tag within the given path.
+func (s *TagService) UpdateTagByPath(path *TagPath, name string, opt *TagOptions, options ...RequestOptionFunc) error {
+	req, err := s.client.NewRequest("PUT", path.objectURL("tags", name), opt, options...)
 	if err != nil {
 		return err
 	}
@@ -143,14 +153,13 @@ func (s *TagService) UpdateTag(channel, device, group, name string, options *Tag
 }
 
 // DeleteTag deletes a tag.
-func (s *TagService) DeleteTag(channel, device, group, name string) error {
-	u := fmt.Sprintf("channels/%s/devices/%s/tag_groups/%s/tags/%s",
-		url.PathEscape(channel),
-		url.PathEscape(device),
-		url.PathEscape(group),
-		url.PathEscape(name),
-	)
-	req, err := s.client.NewRequest("DELETE", u, nil)
+func (s *TagService) DeleteTag(channel, device, group, name string, options ...RequestOptionFunc) error {
+	return s.DeleteTagByPath(NewTagPath(channel, device, group), name, options...)
+}
+
+// DeleteTagByPath deletes a tag within the given path.
+func (s *TagService) DeleteTagByPath(path *TagPath, name string, options ...RequestOptionFunc) error {
+	req, err := s.client.NewRequest("DELETE", path.objectURL("tags", name), nil, options...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,160 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex
+
+import (
+	"sort"
+	"time"
+)
+
+// TransactionLogService handles communication with the transaction log
+// related methods of the KEPServerEX API.
+type TransactionLogService struct {
+	client *Client
+}
+
+// Transaction represents a KEPServerEX transaction log entry, recording a
+// change made to the project through the Configuration API.
+type Transaction struct {
+	Timestamp Timestamp `json:"timestamp"`
+	User      string    `json:"user_name"`
+	Source    string    `json:"source"`
+	Action    string    `json:"action"`
+	Object    string    `json:"object"`
+	Data      string    `json:"data"`
+}
+
+// ListTransactionsOptions represents the available ListTransactions options.
+type ListTransactionsOptions struct {
+	Limit *int       `url:"limit,omitempty"`
+	Start *Timestamp `url:"start,omitempty"`
+	End   *Timestamp `url:"end,omitempty"`
+}
+
+// ListTransactions gets a list of transaction log entries, sorted from oldest
+// to newest.
+func (s *TransactionLogService) ListTransactions(opt *ListTransactionsOptions, options ...RequestOptionFunc) ([]*Transaction, error) {
+	req, err := s.client.newRootRequest("GET", "transaction_log", opt, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []*Transaction
+	if err = s.client.Do(req, &transactions); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].Timestamp.Before(transactions[j].Timestamp.Time)
+	})
+
+	return transactions, nil
+}
+
+// A TransactionIterator iterates over the transaction log entries within a
+// time range, requesting them from the server one page at a time.
+type TransactionIterator struct {
+	s       *TransactionLogService
+	opt     ListTransactionsOptions
+	options []RequestOptionFunc
+
+	page []*Transaction
+	seen map[Transaction]bool
+	cur  *Transaction
+	done bool
+	err  error
+}
+
+// IterateTransactions returns an iterator over all transaction log entries
+// matching the given options, from oldest to newest. The Limit option sets
+// the page size used while iterating and defaults to 1000. The page size
+// should be larger than the number of transactions logged within a single
+// millisecond, as otherwise some of them are skipped.
+func (s *TransactionLogService) IterateTransactions(opt *ListTransactionsOptions, options ...RequestOptionFunc) *TransactionIterator {
+	it := &TransactionIterator{
+		s:       s,
+		options: options,
+		seen:    make(map[Transaction]bool),
+	}
+	if opt != nil {
+		it.opt = *opt
+	}
+	if it.opt.Limit == nil || *it.opt.Limit <= 0 {
+		it.opt.Limit = Int(1000)
+	}
+	return it
+}
+
+// Next advances the iterator to the next transaction, which is then available
+// through the Transaction method. It returns false when there are no more
+// transactions or an error occurred.
+func (it *TransactionIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			it.cur = nil
+			return false
+		}
+		it.fetch()
+	}
+
+	it.cur, it.page = it.page[0], it.page[1:]
+
+	return true
+}
+
+// fetch requests the next page of transactions. The start filter is inclusive,
+// so the next page starts at the last timestamp seen and the transactions seen
+// at that timestamp are skipped.
+func (it *TransactionIterator) fetch() {
+	list, err := it.s.ListTransactions(&it.opt, it.options...)
+	if err != nil {
+		it.err = err
+		return
+	}
+	it.done = len(list) < *it.opt.Limit
+
+	fresh := 0
+	for _, t := range list {
+		if it.seen[*t] {
+			continue
+		}
+		if it.opt.Start == nil || !t.Timestamp.Equal(it.opt.Start.Time) {
+			it.opt.Start = NewTimestamp(t.Timestamp.Time)
+			it.seen = make(map[Transaction]bool)
+		}
+		it.seen[*t] = true
+		it.page = append(it.page, t)
+		fresh++
+	}
+
+	// If a full page was seen before, all of its transactions share the
+	// last timestamp, so move past it to prevent an endless loop.
+	if !it.done && fresh == 0 {
+		it.opt.Start = NewTimestamp(it.opt.Start.Add(time.Millisecond))
+		it.seen = make(map[Transaction]bool)
+	}
+}
+
+// Transaction returns the current transaction.
+func (it *TransactionIterator) Transaction() *Transaction {
+	return it.cur
+}
+
+// Err returns the error, if any, that occurred while iterating.
+func (it *TransactionIterator) Err() error {
+	return it.err
+}
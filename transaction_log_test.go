@@ -0,0 +1,186 @@
+//
+// Copyright 2019, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kepserverex_test
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	kepserverex "github.com/svanharmelen/go-kepserverex"
+	"github.com/svanharmelen/go-kepserverex/kepserverextest"
+)
+
+// requestCounter counts the requests sent through it.
+type requestCounter struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (c *requestCounter) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.n++
+	c.mu.Unlock()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newTransactionLog returns a fake server with transactions for the given
+// objects, logged at the given offsets in milliseconds from base, and a
+// client counting its requests.
+func newTransactionLog(t *testing.T, base time.Time, offsets map[string]int, order []string) (*kepserverextest.Server, *kepserverex.Client, *requestCounter) {
+	s := kepserverextest.NewServer("user", "secret")
+	for _, object := range order {
+		s.AddTransaction(kepserverex.Transaction{
+			Timestamp: kepserverex.Timestamp{Time: base.Add(time.Duration(offsets[object]) * time.Millisecond)},
+			User:      "engineer",
+			Action:    "Modify",
+			Object:    object,
+		})
+	}
+
+	counter := new(requestCounter)
+	c, err := kepserverex.NewClient(&http.Client{Transport: counter}, "", s.Username, s.Password)
+	if err != nil {
+		s.Close()
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := c.SetBaseURL(s.URL); err != nil {
+		s.Close()
+		t.Fatalf("Failed to set base URL: %v", err)
+	}
+
+	return s, c, counter
+}
+
+func iterateObjects(t *testing.T, it *kepserverex.TransactionIterator) []string {
+	var objects []string
+	for it.Next() {
+		objects = append(objects, it.Transaction().Object)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("TransactionIterator returned error: %v", err)
+	}
+	return objects
+}
+
+func expectObjects(t *testing.T, got []string, want ...string) {
+	if len(got) != len(want) {
+		t.Fatalf("iterated over %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("iterated over %v, want %v", got, want)
+		}
+	}
+}
+
+func TestTransactionIteratorPaging(t *testing.T) {
+	base := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	order := []string{"T1", "T2", "T3", "T4", "T5", "T6", "T7"}
+	offsets := map[string]int{"T1": 0, "T2": 1, "T3": 2, "T4": 3, "T5": 4, "T6": 5, "T7": 6}
+
+	s, c, counter := newTransactionLog(t, base, offsets, order)
+	defer s.Close()
+
+	it := c.TransactionLog.IterateTransactions(&kepserverex.ListTransactionsOptions{
+		Limit: kepserverex.Int(3),
+		Start: kepserverex.NewTimestamp(base),
+	})
+	expectObjects(t, iterateObjects(t, it), order...)
+
+	// Every next page starts at the last timestamp of the previous page,
+	// so T1-T3, T3-T5, T5-T7 and T7.
+	if counter.n != 4 {
+		t.Errorf("iterating sent %d requests, want 4", counter.n)
+	}
+
+	// Once done, Next keeps returning false without further requests.
+	if it.Next() || it.Transaction() != nil || counter.n != 4 {
+		t.Errorf("Next after the last transaction returned true or sent a request")
+	}
+}
+
+func TestTransactionIteratorEqualTimestamps(t *testing.T) {
+	base := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	order := []string{"T1", "T2a", "T2b", "T2c", "T3"}
+	offsets := map[string]int{"T1": 0, "T2a": 1, "T2b": 1, "T2c": 1, "T3": 2}
+
+	s, c, _ := newTransactionLog(t, base, offsets, order)
+	defer s.Close()
+
+	it := c.TransactionLog.IterateTransactions(&kepserverex.ListTransactionsOptions{
+		Limit: kepserverex.Int(3),
+		Start: kepserverex.NewTimestamp(base),
+	})
+	expectObjects(t, iterateObjects(t, it), order...)
+}
+
+func TestTransactionIteratorBeyondPageSize(t *testing.T) {
+	base := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	order := []string{"T1a", "T1b", "T1c", "T1d", "T2"}
+	offsets := map[string]int{"T1a": 0, "T1b": 0, "T1c": 0, "T1d": 0, "T2": 1}
+
+	s, c, _ := newTransactionLog(t, base, offsets, order)
+	defer s.Close()
+
+	// More transactions share a timestamp than fit in a page, so the ones
+	// beyond the page size are skipped as documented.
+	it := c.TransactionLog.IterateTransactions(&kepserverex.ListTransactionsOptions{
+		Limit: kepserverex.Int(3),
+		Start: kepserverex.NewTimestamp(base),
+	})
+	expectObjects(t, iterateObjects(t, it), "T1a", "T1b", "T1c", "T2")
+}
+
+func TestTransactionIteratorRange(t *testing.T) {
+	base := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	order := []string{"T1", "T2", "T3", "T4", "T5"}
+	offsets := map[string]int{"T1": 0, "T2": 10, "T3": 20, "T4": 30, "T5": 40}
+
+	s, c, _ := newTransactionLog(t, base, offsets, order)
+	defer s.Close()
+
+	it := c.TransactionLog.IterateTransactions(&kepserverex.ListTransactionsOptions{
+		Limit: kepserverex.Int(2),
+		Start: kepserverex.NewTimestamp(base.Add(10 * time.Millisecond)),
+		End:   kepserverex.NewTimestamp(base.Add(30 * time.Millisecond)),
+	})
+	expectObjects(t, iterateObjects(t, it), "T2", "T3", "T4")
+}
+
+func TestTransactionIteratorError(t *testing.T) {
+	s := kepserverextest.NewServer("user", "secret")
+	defer s.Close()
+
+	c, err := kepserverex.NewClient(nil, "", "user", "wrong")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if err := c.SetBaseURL(s.URL); err != nil {
+		t.Fatalf("Failed to set base URL: %v", err)
+	}
+
+	it := c.TransactionLog.IterateTransactions(nil)
+	if it.Next() {
+		t.Fatal("Next returned true, want false")
+	}
+	if !errors.Is(it.Err(), kepserverex.ErrUnauthorized) {
+		t.Errorf("Err returned %v, want %v", it.Err(), kepserverex.ErrUnauthorized)
+	}
+}